@@ -117,6 +117,21 @@ func (b *beam2d) localNoHingeTangent(l float64) *mat.SymDense {
 func (b *beam2d) localNoHingeLoads(l float64) *mat.VecDense {
 	var rz0, rphiy0, rz1, rphiy1 float64
 
+	distributed := func(q PolyPiece) {
+		// Integrals with the Hermite shape functions, which are (with ξ = x/l)
+		// 1 - 3ξ² + 2ξ³, l·(ξ - 2ξ² + ξ³), 3ξ² - 2ξ³, and l·(-ξ² + ξ³).
+		r := consistentLoads(q,
+			[]float64{1, 0, -3 / (l * l), 2 / (l * l * l)},
+			[]float64{0, 1, -2 / l, 1 / (l * l)},
+			[]float64{0, 0, 3 / (l * l), -2 / (l * l * l)},
+			[]float64{0, 0, -1 / l, 1 / (l * l)},
+		)
+		rz0 += r[0]
+		rphiy0 -= r[1]
+		rz1 += r[2]
+		rphiy1 -= r[3]
+	}
+
 	for _, bc := range b.loads {
 		loadDispatch(bc,
 			func(load *neumannConcentrated) {
@@ -145,12 +160,16 @@ func (b *beam2d) localNoHingeLoads(l float64) *mat.VecDense {
 			},
 			func(load *neumannLinear) {
 				if load.kind == Uz {
-					q0, q1 := load.first, load.last
-					rz0 += (7*q0 + 3*q1) * l / 20
-					rz1 += (3*q0 + 7*q1) * l / 20
-					rphiy0 -= (3*q0 + 2*q1) * l * l / 60
-					rphiy1 += (2*q0 + 3*q1) * l * l / 60
+					distributed(load.piece(l))
+				}
+			},
+			func(load *neumannPolynomial) {
+				if load.kind == Uz {
+					distributed(load.piece(l))
 				}
+			},
+			func(*neumannInitialStrain) {
+				// Axial only, not accepted by AddLoad
 			})
 	}
 
@@ -177,19 +196,33 @@ func (b *beam2d) indicesAsArray() *[6]Index {
 }
 
 func (b *beam2d) AddLoad(bc NeumannElementBC) bool {
+	if global, ok := bc.(*neumannGlobal); ok {
+		axial, transverse := global.local(sineCosine2d(b.n0, b.n1))
+
+		if axial != nil || transverse != nil && !b.AddLoad(transverse) {
+			return false
+		} else if transverse != nil {
+			b.addGlobalPart(global, transverse)
+		}
+
+		return true
+	}
+
 	supported := false
 
 	loadDispatch(bc,
 		func(l *neumannConcentrated) { supported = l.kind == Uz || l.kind == Phiy },
 		func(l *neumannConstant) { supported = l.kind == Uz },
-		func(l *neumannLinear) { supported = l.kind == Uz })
+		func(l *neumannLinear) { supported = l.kind == Uz },
+		func(l *neumannPolynomial) { supported = l.kind == Uz },
+		func(*neumannInitialStrain) { supported = false })
 
 	return supported && b.oneDimElement.AddLoad(bc)
 }
 
 func (b *beam2d) Interpolate(indices EqLayout, which Fct, d *mat.VecDense) PolySequence {
 	switch which {
-	case FctVz, FctMy, FctPhiy, FctUz:
+	case FctVz, FctMy, FctPhiy, FctUz, FctKappay:
 	default:
 		return nil
 	}
@@ -204,7 +237,7 @@ func (b *beam2d) Interpolate(indices EqLayout, which Fct, d *mat.VecDense) PolyS
 	case FctMy:
 		return my
 	case FctVz:
-		return transform(func(p PolyPiece) PolyPiece { return p.derive() }, my)
+		return my.Derive()
 	}
 
 	// In what follows
@@ -214,11 +247,15 @@ func (b *beam2d) Interpolate(indices EqLayout, which Fct, d *mat.VecDense) PolyS
 	myOverEI := my // Just a renaming, coefficients are shallow-copied
 	myOverEI.multiply(1 / EI)
 
-	phiy := my.integrate(phiy0)
+	if which == FctKappay {
+		return myOverEI
+	}
+
+	phiy := my.Integrate(phiy0)
 	// Given the local co-system, we have d/dx w(x) = -phiy(x). We integrate first without this sign
 	// flip, but since uz0 is already in the local co-system, we need to invert its sign and then
 	// invert the sign of the entire polynomial altogether afterwards.
-	uz := phiy.integrate(-uz0)
+	uz := phiy.Integrate(-uz0)
 	uz.multiply(-1)
 
 	switch which {
@@ -257,9 +294,19 @@ func (b *beam2d) InterpolateMy(indices EqLayout, d *mat.VecDense) PolySequence {
 			},
 			func(load *neumannLinear) {
 				if load.kind == Uz {
-					q0, qE := load.first, load.last
-					p = PolyPiece{X0: 0, XE: l, Coeff: []float64{0, 0, -0.5 * q0, -(qE - q0) / (6 * l)}}
+					// Possibly partial, so there can be more than one piece.
+					_, my := loadIntegrals(load.piece(l), l)
+					result = append(result, my...)
 				}
+			},
+			func(load *neumannPolynomial) {
+				if load.kind == Uz {
+					_, my := loadIntegrals(load.piece(l), l)
+					result = append(result, my...)
+				}
+			},
+			func(*neumannInitialStrain) {
+				// Axial only, not accepted by AddLoad
 			})
 
 		result = append(result, p)
@@ -6,26 +6,54 @@ import (
 	"gonum.org/v1/gonum/mat"
 )
 
-type cholesky struct{}
+type cholesky struct {
+	// Pointers to distinct zero-size values may be equal, which would make different instances
+	// indistinguishable for the reuse of factorizations, see sameFactorizer.
+	_ byte
+}
+
+func (c *cholesky) SolveLinearSystem(a mat.Symmetric, b, x *mat.VecDense) error {
+	factorization, err := c.Factorize(a)
+
+	if err != nil {
+		return err
+	}
 
-func (c *cholesky) SolveLinearSystem(a mat.Symmetric, b, x *mat.VecDense) (result error) {
+	return factorization.Solve(b, x)
+}
+
+func (c *cholesky) Factorize(a mat.Symmetric) (result Factorization, err error) {
 	// Gonum panics when the input matrix is singular. Since we'd like to try and handle such a case
 	// more gracefully, we turn this into an error instead.
 	defer func() {
 		if r := recover(); r != nil {
-			result = fmt.Errorf("could not Cholesky-factorise coefficient matrix: %v", r)
+			result, err = nil, fmt.Errorf("could not Cholesky-factorise coefficient matrix: %v", r)
 		}
 	}()
 
-	var ch mat.Cholesky
+	factorization := &choleskyFactorization{}
 
-	if ok := ch.Factorize(a); !ok {
-		det := ch.Det()
-		return fmt.Errorf("failed to compute Cholesky factorisation, deteterminant = %v", det)
+	if ok := factorization.ch.Factorize(a); !ok {
+		det := factorization.ch.Det()
+		return nil, fmt.Errorf("failed to compute Cholesky factorisation, deteterminant = %v", det)
 	}
 
-	if err := ch.SolveVecTo(x, b); err != nil {
-		det := ch.Det()
+	return factorization, nil
+}
+
+type choleskyFactorization struct {
+	ch mat.Cholesky
+}
+
+func (f *choleskyFactorization) Solve(b, x *mat.VecDense) (result error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = fmt.Errorf("could not solve Cholesky-factorised system: %v", r)
+		}
+	}()
+
+	if err := f.ch.SolveVecTo(x, b); err != nil {
+		det := f.ch.Det()
 		return fmt.Errorf("failed to solve Cholesky-factorised system, det = %v", det)
 	}
 
@@ -33,6 +61,7 @@ func (c *cholesky) SolveLinearSystem(a mat.Symmetric, b, x *mat.VecDense) (resul
 }
 
 // NewCholeskySolver creates a Cholesky solver for symmetric positive definite coefficient matrices.
+// It implements [Factorizer].
 func NewCholeskySolver() EquationSolver {
 	return &cholesky{}
 }
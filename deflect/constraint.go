@@ -0,0 +1,266 @@
+package deflect
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// LinearTerm pairs a symbolic index with a coefficient, e.g. as one summand of a linear combination
+// of primary nodal values.
+type LinearTerm struct {
+	Index
+	Coefficient float64
+}
+
+// NewLinearConstraint instantiates a transformer and a Dirichlet boundary condition that together
+// enforce the linear multi-point constraint
+//
+//	u_s = Σ a_j·u_j + c
+//
+// where u_s is the slave degree of freedom, u_j are the masters with coefficients a_j, and c is a
+// constant. The slave is eliminated by a coordinate transformation: afterwards, the slave index
+// denotes the deviation from Σ a_j·u_j, and the returned Dirichlet BC prescribes it to be c. The
+// reaction reported for the slave index is hence the constraint force. Slaves must not be
+// constrained by other Dirichlet BCs, and they must not be masters of another constraint, which
+// [NewEqLayout] checks. Masters refer to global coordinates, so constraints must precede supports
+// in [Problem.EqTransforms].
+func NewLinearConstraint(
+	slave Index,
+	masters []LinearTerm,
+	constant float64,
+) (Transformer, NodalValue, error) {
+	if slices.ContainsFunc(masters, func(t LinearTerm) bool { return t.Index == slave }) {
+		return nil, NodalValue{}, fmt.Errorf(
+			"slave %v/%v can't be its own master",
+			slave.NodalID,
+			slave.Dof,
+		)
+	}
+
+	return &linearConstraint{slave: slave, masters: slices.Clone(masters)},
+		NodalValue{Index: slave, Value: constant},
+		nil
+}
+
+type linearConstraint struct {
+	slave   Index
+	masters []LinearTerm
+	// Scratch buffers for the tangent transformation, one row per master.
+	rows []*mat.VecDense
+}
+
+func (l *linearConstraint) Pre(indices EqLayout, k *mat.SymDense, r, d *mat.VecDense) {
+	// The transformation is u = t·ũ with t = I + e_s·aᵀ, where e_s is the unit vector of the slave
+	// and a holds the master coefficients. Since a_s = 0, this yields
+	//   tᵀ·k·t = k + a·k_sᵀ + k_s·aᵀ + k_ss·a·aᵀ
+	// where k_s is the slave column of k. Only master rows and columns change. The prescribed values
+	// in d are already expressed in transformed coordinates, so d is left as is.
+	dim := k.SymmetricDim()
+	s := indices.mapOne(l.slave)
+	masters := l.mapMasters(indices)
+
+	if len(l.rows) != len(masters) || (len(l.rows) > 0 && l.rows[0].Len() != dim) {
+		l.rows = make([]*mat.VecDense, len(masters))
+		for i := range l.rows {
+			l.rows[i] = mat.NewVecDense(dim, nil)
+		}
+	}
+
+	a := mat.NewVecDense(dim, nil)
+	for i, j := range masters {
+		a.SetVec(j, a.AtVec(j)+l.masters[i].Coefficient)
+	}
+
+	kss := k.At(s, s)
+
+	for i, j := range masters {
+		aj := a.AtVec(j)
+		for n := range dim {
+			l.rows[i].SetVec(n, k.At(j, n)+aj*k.At(s, n)+k.At(s, j)*a.AtVec(n)+kss*aj*a.AtVec(n))
+		}
+	}
+
+	// Spill scratch buffers into the destination matrix only after all rows are computed, since the
+	// computation relies on untransformed entries.
+	for i, j := range masters {
+		for n := range dim {
+			k.SetSym(j, n, l.rows[i].AtVec(n))
+		}
+	}
+
+	rs := r.AtVec(s)
+
+	for _, j := range uniqueInts(masters) {
+		r.SetVec(j, r.AtVec(j)+a.AtVec(j)*rs)
+	}
+}
+
+func (l *linearConstraint) Post(indices EqLayout, r, d *mat.VecDense) {
+	// Computes d = t·d̃ and r = t⁻ᵀ·r̃, where t⁻¹ = I - e_s·aᵀ.
+	s := indices.mapOne(l.slave)
+	masters := l.mapMasters(indices)
+	ds, rs := d.AtVec(s), r.AtVec(s)
+
+	for i, j := range masters {
+		a := l.masters[i].Coefficient
+		ds += a * d.AtVec(j)
+		r.SetVec(j, r.AtVec(j)-a*rs)
+	}
+
+	d.SetVec(s, ds)
+}
+
+// checkSlaves makes sure that the slave of every linear constraint among transformers carries
+// exactly one Dirichlet BC, i.e., the one returned by [NewLinearConstraint], and that it is neither
+// the slave of another constraint, nor the master of one, nor transformed by a support. All of
+// these would require a particular order of transformations, which isn't supported.
+func checkSlaves(transformers []Transformer, dirichlet []NodalValue) error {
+	slaves := map[Index]int{}
+	// Indices that other transformers read or modify:
+	linked := map[Index]struct{}{}
+	var err error
+
+	for _, t := range transformers {
+		switch concrete := t.(type) {
+		case *linearConstraint:
+			slaves[concrete.slave]++
+
+			for _, master := range concrete.masters {
+				linked[master.Index] = struct{}{}
+			}
+		case *inclinedSupport:
+			linked[concrete.from] = struct{}{}
+			linked[concrete.to] = struct{}{}
+		case *SkewedSupport:
+			for _, group := range concrete.groups {
+				for _, index := range group.indices {
+					linked[index] = struct{}{}
+				}
+			}
+		}
+	}
+
+	prescribed := map[Index]int{}
+
+	for _, bc := range dirichlet {
+		prescribed[bc.Index]++
+	}
+
+	for slave, n := range slaves {
+		name := fmt.Sprintf("%v/%v", slave.NodalID, slave.Dof)
+
+		if n > 1 {
+			err = errors.Join(err, fmt.Errorf("%v is slave of multiple constraints", name))
+		}
+
+		if _, ok := linked[slave]; ok {
+			err = errors.Join(err, fmt.Errorf("%v is slave and part of another constraint", name))
+		}
+
+		if bcs := prescribed[slave]; bcs == 0 {
+			err = errors.Join(err, fmt.Errorf("slave %v lacks the Dirichlet BC of its constraint", name))
+		} else if bcs > n {
+			err = errors.Join(err, fmt.Errorf("slave %v must not carry other Dirichlet BCs", name))
+		}
+	}
+
+	return err
+}
+
+func (l *linearConstraint) mapMasters(indices EqLayout) []int {
+	return transform(func(t LinearTerm) int { return indices.mapOne(t.Index) }, l.masters)
+}
+
+// uniqueInts returns the sorted, de-duplicated values in s without mutating it.
+func uniqueInts(s []int) []int {
+	result := slices.Clone(s)
+	slices.Sort(result)
+	return slices.Compact(result)
+}
+
+// NewRigidLink couples the given degrees of freedom of the slave node to the master node, as if
+// both were connected by a rigid bar. For every given translational degree of freedom, the slave
+// displacement is u_s = u_m + φ_m × (x_s - x_m), where only rotations φ_m that are part of dofs
+// contribute. Every given rotational degree of freedom is identical at both nodes. The given
+// degrees of freedom must exist at both nodes, and one linear constraint and Dirichlet BC is
+// returned per degree of freedom (see [NewLinearConstraint]).
+func NewRigidLink(master, slave *Node, dofs []Dof) ([]Transformer, []NodalValue, error) {
+	if master.ID == slave.ID {
+		return nil, nil, fmt.Errorf("can't link node %v rigidly to itself", master.ID)
+	}
+
+	rx, ry, rz := slave.X-master.X, slave.Y-master.Y, slave.Z-master.Z
+	// Each translational dof is coupled to two rotations through the cross product φ × r:
+	arms := map[Dof][]LinearTerm{
+		Ux: {{Index: Index{Dof: Phiy}, Coefficient: rz}, {Index: Index{Dof: Phiz}, Coefficient: -ry}},
+		Uy: {{Index: Index{Dof: Phiz}, Coefficient: rx}, {Index: Index{Dof: Phix}, Coefficient: -rz}},
+		Uz: {{Index: Index{Dof: Phix}, Coefficient: ry}, {Index: Index{Dof: Phiy}, Coefficient: -rx}},
+	}
+
+	transformers := make([]Transformer, 0, len(dofs))
+	bcs := make([]NodalValue, 0, len(dofs))
+	var err error
+
+	for _, dof := range dofs {
+		terms := []LinearTerm{{Index: Index{NodalID: master.ID, Dof: dof}, Coefficient: 1}}
+
+		for _, arm := range arms[dof] {
+			if arm.Coefficient != 0 && slices.Contains(dofs, arm.Dof) {
+				terms = append(terms, LinearTerm{
+					Index:       Index{NodalID: master.ID, Dof: arm.Dof},
+					Coefficient: arm.Coefficient,
+				})
+			}
+		}
+
+		slaveIndex := Index{NodalID: slave.ID, Dof: dof}
+		transformer, bc, errSingle := NewLinearConstraint(slaveIndex, terms, 0)
+
+		if errSingle != nil {
+			err = errors.Join(err, errSingle)
+			continue
+		}
+
+		transformers = append(transformers, transformer)
+		bcs = append(bcs, bc)
+	}
+
+	return transformers, bcs, err
+}
+
+// NewRigidDiaphragm links all slave nodes to the master node so that they move like a floor slab
+// that is rigid in the global x-y plane, but flexible otherwise. The given in-plane degrees of
+// freedom, a subset of Ux, Uy and Phiz, are coupled through rigid links and must exist at all
+// nodes. Models in the x-z plane lack Uy and Phiz, so their diaphragms only couple Ux.
+func NewRigidDiaphragm(
+	master *Node,
+	slaves []*Node,
+	dofs []Dof,
+) ([]Transformer, []NodalValue, error) {
+	if len(dofs) == 0 {
+		return nil, nil, fmt.Errorf("rigid diaphragm at %v must couple at least one dof", master.ID)
+	}
+
+	for _, dof := range dofs {
+		if dof != Ux && dof != Uy && dof != Phiz {
+			return nil, nil, fmt.Errorf("rigid diaphragm at %v can't couple out-of-plane %v",
+				master.ID, dof)
+		}
+	}
+
+	var transformers []Transformer
+	var bcs []NodalValue
+	var err error
+
+	for _, slave := range slaves {
+		t, bc, errLink := NewRigidLink(master, slave, dofs)
+		transformers = append(transformers, t...)
+		bcs = append(bcs, bc...)
+		err = errors.Join(err, errLink)
+	}
+
+	return transformers, bcs, err
+}
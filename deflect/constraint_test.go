@@ -0,0 +1,207 @@
+package deflect
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestLinearConstraintTransformation(t *testing.T) {
+	// Same idea as for the inclined support: compare the in-place transformation against the one
+	// with a dense transformation matrix t = I + e_s·aᵀ.
+	slave := Index{NodalID: "S", Dof: Uz}
+	m0, m1 := Index{NodalID: "A", Dof: Uz}, Index{NodalID: "A", Dof: Phiy}
+	indices := EqLayout{indices: map[Index]int{slave: 7, m0: 2, m1: 15}, inverse: nil}
+	dim := 20
+
+	masters := []LinearTerm{{Index: m0, Coefficient: 1.5}, {Index: m1, Coefficient: -0.25}}
+	transformer, bc, err := NewLinearConstraint(slave, masters, 0.1)
+
+	if err != nil {
+		t.Fatalf("Expected valid constraint, got error: %v", err)
+	} else if bc.Index != slave || bc.Value != 0.1 {
+		t.Errorf("Expected Dirichlet BC on slave with constant value, got %v", bc)
+	}
+
+	k, r, d := matricesToTransform(dim)
+	kref, rref, dref := referenceMatricesToTransform(k, r, d)
+	trafo := mat.NewDense(dim, dim, nil)
+
+	for i := range dim {
+		trafo.Set(i, i, 1)
+	}
+
+	trafo.Set(7, 2, 1.5)
+	trafo.Set(7, 15, -0.25)
+
+	kref.Mul(trafo.T(), kref)
+	kref.Mul(kref, trafo)
+	rref.MulVec(trafo.T(), rref)
+
+	transformer.Pre(indices, k, r, d)
+
+	if !mat.EqualApprox(k, kref, 1e-8) {
+		t.Errorf("Expected Pre operation to compute tᵀ·k·t, but reference result differs")
+	}
+	if !mat.EqualApprox(r, rref, 1e-8) {
+		t.Errorf("Expected Pre operation to compute tᵀ·r, but reference result differs")
+	}
+	if !mat.EqualApprox(d, dref, 1e-8) {
+		t.Errorf("Expected Pre operation to leave d untouched")
+	}
+
+	transformer.Post(indices, r, d)
+
+	var inverse mat.Dense
+	if err := inverse.Inverse(trafo); err != nil {
+		t.Fatalf("Can't invert reference transformation: %v", err)
+	}
+
+	dref.MulVec(trafo, dref)
+	rref.MulVec(inverse.T(), rref)
+
+	if !mat.EqualApprox(d, dref, 1e-8) {
+		t.Errorf("Expected Post to compute t·d, but reference result differs")
+	}
+	if !mat.EqualApprox(r, rref, 1e-8) {
+		t.Errorf("Expected Post to compute t⁻ᵀ·r, but reference result differs")
+	}
+}
+
+func TestLinearConstraintRejectsSelfReference(t *testing.T) {
+	slave := Index{NodalID: "A", Dof: Ux}
+	_, _, err := NewLinearConstraint(slave, []LinearTerm{{Index: slave, Coefficient: 1}}, 0)
+
+	if err == nil {
+		t.Errorf("Expected error when slave is its own master")
+	}
+}
+
+func TestEqLayoutChecksSlaves(t *testing.T) {
+	slave, master := Index{NodalID: "B", Dof: Uz}, Index{NodalID: "A", Dof: Uz}
+	other := Index{NodalID: "A", Dof: Ux}
+	constraint := func(slave, master Index) (Transformer, NodalValue) {
+		terms := []LinearTerm{{Index: master, Coefficient: 1}}
+		transformer, bc, err := NewLinearConstraint(slave, terms, 0)
+		if err != nil {
+			t.Fatalf("Expected valid constraint, got %v", err)
+		}
+		return transformer, bc
+	}
+	link, linkBC := constraint(slave, master)
+	chained, chainedBC := constraint(master, other)
+	duplicate, _ := constraint(slave, other)
+	inclined, inclinedBC := NewInclinedSupport(Index{NodalID: "B", Dof: Ux}, slave, 0.5)
+
+	cases := []struct {
+		name         string
+		transformers []Transformer
+		dirichlet    []NodalValue
+		valid        bool
+	}{
+		{"valid", []Transformer{link}, []NodalValue{linkBC}, true},
+		{"missing BC", []Transformer{link}, nil, false},
+		{"additional BC", []Transformer{link}, []NodalValue{linkBC, linkBC}, false},
+		{"slave twice", []Transformer{link, duplicate}, []NodalValue{linkBC}, false},
+		{"slave is master", []Transformer{link, chained}, []NodalValue{linkBC, chainedBC}, false},
+		{"inclined slave", []Transformer{link, inclined}, []NodalValue{linkBC, inclinedBC}, false},
+	}
+
+	for _, test := range cases {
+		p := cantileverTestProblem(t)
+		p.EqTransforms, p.Dirichlet = test.transformers, test.dirichlet
+
+		if _, err := NewEqLayout(&p); (err == nil) != test.valid {
+			t.Errorf("%v: expected valid layout to be %v, got error %v", test.name, test.valid, err)
+		}
+	}
+}
+
+func TestRigidLinkTerms(t *testing.T) {
+	master := &Node{ID: "M", X: 1, Y: 2, Z: 3}
+	slave := &Node{ID: "S", X: 2, Y: 4, Z: 6}
+
+	cases := []struct {
+		dofs     []Dof
+		expected map[Dof][]LinearTerm
+	}{
+		{
+			dofs: []Dof{Ux, Uz, Phiy},
+			expected: map[Dof][]LinearTerm{
+				Ux:   {{Index{"M", Ux}, 1}, {Index{"M", Phiy}, 3}},
+				Uz:   {{Index{"M", Uz}, 1}, {Index{"M", Phiy}, -1}},
+				Phiy: {{Index{"M", Phiy}, 1}},
+			},
+		},
+		{
+			dofs: []Dof{Ux, Uy, Phiz},
+			expected: map[Dof][]LinearTerm{
+				Ux:   {{Index{"M", Ux}, 1}, {Index{"M", Phiz}, -2}},
+				Uy:   {{Index{"M", Uy}, 1}, {Index{"M", Phiz}, 1}},
+				Phiz: {{Index{"M", Phiz}, 1}},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		transformers, bcs, err := NewRigidLink(master, slave, c.dofs)
+
+		if err != nil {
+			t.Fatalf("Expected valid rigid link, got error: %v", err)
+		} else if len(transformers) != len(c.dofs) || len(bcs) != len(c.dofs) {
+			t.Fatalf("Expected one constraint per dof, got %v/%v", len(transformers), len(bcs))
+		}
+
+		for _, transformer := range transformers {
+			constraint := transformer.(*linearConstraint)
+			expected := c.expected[constraint.slave.Dof]
+
+			if constraint.slave.NodalID != "S" {
+				t.Errorf("Expected slave node S, got %v", constraint.slave.NodalID)
+			}
+			if !equalTerms(constraint.masters, expected) {
+				t.Errorf("Expected %v terms %v, got %v", constraint.slave.Dof, expected, constraint.masters)
+			}
+		}
+	}
+}
+
+func TestRigidLinkRejectsIdenticalNodes(t *testing.T) {
+	node := &Node{ID: "A"}
+
+	if _, _, err := NewRigidLink(node, node, []Dof{Ux}); err == nil {
+		t.Errorf("Expected error when linking a node to itself")
+	}
+}
+
+func TestRigidDiaphragmRejectsOutOfPlaneDofs(t *testing.T) {
+	master, slave := &Node{ID: "A"}, &Node{ID: "B", X: 1}
+
+	for _, dofs := range [][]Dof{{}, {Ux, Uz}, {Phiy}} {
+		if _, _, err := NewRigidDiaphragm(master, []*Node{slave}, dofs); err == nil {
+			t.Errorf("Expected error for rigid diaphragm coupling %v", dofs)
+		}
+	}
+
+	transformers, _, err := NewRigidDiaphragm(master, []*Node{slave, {ID: "C", Y: 1}}, []Dof{Ux})
+
+	if err != nil {
+		t.Fatalf("Expected valid rigid diaphragm, got error: %v", err)
+	} else if len(transformers) != 2 {
+		t.Errorf("Expected one constraint per slave, got %v", len(transformers))
+	}
+}
+
+func equalTerms(lhs, rhs []LinearTerm) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i := range lhs {
+		if lhs[i] != rhs[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -8,6 +8,8 @@ import (
 
 type constantsCrossSection struct {
 	area, iyy, izz, ixx, roll float64
+	// Optional properties for stresses, nil when not given.
+	zTop, zBottom, avz *float64
 }
 
 // NewConstantsCrossSections instantiates a cross section with all parameters specified as
@@ -18,9 +20,11 @@ type constantsCrossSection struct {
 // Optional parameters are
 // - Ixx
 // - roll (angle)
+// - zTop, zBottom (local z coordinates of the extreme fibres, see [StressSection])
+// - Avz (shear area, see [StressSection])
 // Returns an error if any of the mandatory parameters is not positive or can't be found in param.
-// An error is also returned if an optional parameter is negative. No error is returned if an
-// optional parameter is zero.
+// An error is also returned if an optional parameter is negative, except zTop, which must not be
+// positive. No error is returned if an optional parameter is zero.
 func NewConstantsCrossSections(param map[string]float64) (CrossSection, error) {
 	var cs constantsCrossSection
 	var err error
@@ -46,6 +50,28 @@ func NewConstantsCrossSections(param map[string]float64) (CrossSection, error) {
 		err = errors.Join(err, fmt.Errorf("negative cross section constant Ixx = %v", cs.ixx))
 	}
 
+	optional := func(key string) *float64 {
+		if v, ok := param[key]; ok {
+			return &v
+		}
+
+		return nil
+	}
+
+	cs.zTop, cs.zBottom, cs.avz = optional("zTop"), optional("zBottom"), optional("Avz")
+
+	if cs.zTop != nil && *cs.zTop > 0 {
+		err = errors.Join(err, fmt.Errorf("positive cross section constant zTop = %v", *cs.zTop))
+	}
+
+	if cs.zBottom != nil && *cs.zBottom < 0 {
+		err = errors.Join(err, fmt.Errorf("negative cross section constant zBottom = %v", *cs.zBottom))
+	}
+
+	if cs.avz != nil && *cs.avz < 0 {
+		err = errors.Join(err, fmt.Errorf("negative cross section constant Avz = %v", *cs.avz))
+	}
+
 	return &cs, err
 }
 
@@ -69,6 +95,22 @@ func (c *constantsCrossSection) RollAngle() float64 {
 	return c.roll
 }
 
+func (c *constantsCrossSection) ExtremeFibres() (zTop, zBottom float64, err error) {
+	if c.zTop == nil || c.zBottom == nil {
+		return 0, 0, errors.New("cross section constants zTop and/or zBottom not given")
+	}
+
+	return *c.zTop, *c.zBottom, nil
+}
+
+func (c *constantsCrossSection) ShearAreaZ() (float64, error) {
+	if c.avz == nil || *c.avz == 0 {
+		return 0, errors.New("cross section constant Avz not given")
+	}
+
+	return *c.avz, nil
+}
+
 type rectangular struct {
 	b, h, roll float64
 }
@@ -113,3 +155,13 @@ func (r *rectangular) Ixx() float64 {
 func (r *rectangular) RollAngle() float64 {
 	return r.roll
 }
+
+func (r *rectangular) ExtremeFibres() (zTop, zBottom float64, err error) {
+	return -r.h / 2, r.h / 2, nil
+}
+
+// ShearAreaZ returns 2/3 of the area, since the largest shear stress of the parabolic distribution
+// is 3/2·Vz/A.
+func (r *rectangular) ShearAreaZ() (float64, error) {
+	return 2.0 / 3.0 * r.b * r.h, nil
+}
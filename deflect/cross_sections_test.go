@@ -73,6 +73,14 @@ func TestConstantsCrossSectionConstruction(t *testing.T) {
 		{params: map[string]float64{"A": 0, "Iyy": 0, "Izz": 0}, failure: true},
 		{params: map[string]float64{"A": 1, "Iyy": 1, "Izz": 1, "unused": -123}, failure: false},
 		{params: map[string]float64{}, failure: true},
+		{
+			params:  map[string]float64{"A": 1, "Iyy": 1, "Izz": 1, "zTop": -1, "zBottom": 2},
+			failure: false,
+		},
+		{params: map[string]float64{"A": 1, "Iyy": 1, "Izz": 1, "zTop": 1}, failure: true},
+		{params: map[string]float64{"A": 1, "Iyy": 1, "Izz": 1, "zBottom": -1}, failure: true},
+		{params: map[string]float64{"A": 1, "Iyy": 1, "Izz": 1, "Avz": 0.5}, failure: false},
+		{params: map[string]float64{"A": 1, "Iyy": 1, "Izz": 1, "Avz": -0.5}, failure: true},
 	}
 
 	for _, test := range cases {
@@ -87,3 +95,45 @@ func TestConstantsCrossSectionConstruction(t *testing.T) {
 		}
 	}
 }
+
+func TestStressSectionProperties(t *testing.T) {
+	rect, _ := NewRectangularCrossSection(0.1, 0.3, 0)
+	withStress, _ := NewConstantsCrossSections(
+		map[string]float64{"A": 1, "Iyy": 1, "Izz": 1, "zTop": -0.1, "zBottom": 0.2, "Avz": 0.5},
+	)
+	withoutStress, _ := NewConstantsCrossSections(map[string]float64{"A": 1, "Iyy": 1, "Izz": 1})
+
+	cases := []struct {
+		name                string
+		cs                  CrossSection
+		zTop, zBottom, area float64
+		failure             bool
+	}{
+		{name: "rectangle", cs: rect, zTop: -0.15, zBottom: 0.15, area: 0.02},
+		{name: "constants", cs: withStress, zTop: -0.1, zBottom: 0.2, area: 0.5},
+		{name: "constants-missing", cs: withoutStress, failure: true},
+	}
+
+	for _, test := range cases {
+		section := test.cs.(StressSection)
+		zTop, zBottom, errFibres := section.ExtremeFibres()
+		area, errArea := section.ShearAreaZ()
+
+		if test.failure {
+			if errFibres == nil || errArea == nil {
+				t.Errorf("%v: expected failures, got %v and %v", test.name, errFibres, errArea)
+			}
+
+			continue
+		}
+
+		approxEq := func(a, b float64) bool { return scalar.EqualWithinAbs(a, b, 1e-12) }
+
+		fibres := approxEq(zTop, test.zTop) && approxEq(zBottom, test.zBottom)
+
+		if !fibres || !approxEq(area, test.area) {
+			t.Errorf("%v: expected %v/%v/%v, got %v/%v/%v (%v, %v)", test.name,
+				test.zTop, test.zBottom, test.area, zTop, zBottom, area, errFibres, errArea)
+		}
+	}
+}
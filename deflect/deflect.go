@@ -1,7 +1,10 @@
 package deflect
 
 import (
+	"context"
+
 	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/spatial/r3"
 )
 
 // Dof describes a degree of freedom symbolically and with an intuitive ordering. The predefined
@@ -21,7 +24,7 @@ const (
 )
 
 // Fct denotes a function to be interpolated on the element level. These can be primary solution
-// variables (nodal values) as well as stresses/internal forces.
+// variables (nodal values), stresses/internal forces, as well as strains and curvatures.
 //
 //go:generate go run golang.org/x/tools/cmd/stringer -type=Fct -trimprefix Fct
 type Fct uint8
@@ -41,6 +44,12 @@ const (
 	FctMy
 	FctMz
 	FctMx
+	// FctEpsx is the total axial strain u'ₓ, including imposed strains.
+	FctEpsx
+	// FctKappay is the curvature φ'y = My/EIyy. There is no curvature about z and no rate of twist,
+	// since no element bends about z or twists yet: [NewFrame3d] only combines a 3d truss with the
+	// beam of [NewFrame2d].
+	FctKappay
 )
 
 // Node describes a mesh vertex by 3-dimensional coordinates and an identifier. Coordinates are
@@ -91,6 +100,17 @@ type CrossSection interface {
 	RollAngle() float64
 }
 
+// StressSection is implemented by a CrossSection that provides the properties to compute stresses
+// from internal forces. Distances are measured from the centroid in the local z direction, which
+// points downward for an element from left to right, so that the top fibre is at a negative z.
+type StressSection interface {
+	// ExtremeFibres returns the local z coordinates of the top and bottom fibres.
+	ExtremeFibres() (zTop, zBottom float64, err error)
+	// ShearAreaZ returns the effective area for a shear force in local z direction, such that the
+	// largest shear stress in the section is Vz/ShearAreaZ.
+	ShearAreaZ() (float64, error)
+}
+
 // NeumannElementBC is an opaque handle to be downcast by element implementations. It is always
 // instantiated with a pointer.
 type NeumannElementBC any
@@ -136,8 +156,9 @@ type Element interface {
 
 // Transformer mutate the system of equation before and after it is solved. For example, an inclined
 // support can be split into a Transformer and a Dirichlet BC, such that they act independently to
-// link degrees of freedom through a trigonometric relation. Transformer instances don't prescribe
-// values in r or d (this is done by NodalBC instances).
+// link degrees of freedom through a trigonometric relation. Linear multi-point constraints and
+// rigid links work the same way. Transformer instances don't prescribe values in r or d (this is
+// done by NodalBC instances).
 type Transformer interface {
 	Pre(indices EqLayout, k *mat.SymDense, r, d *mat.VecDense)
 	Post(indices EqLayout, r, d *mat.VecDense)
@@ -150,20 +171,46 @@ type Problem struct {
 	// Implementation note: nodal BCs are saved separately from basic.Node, since there is no coupling
 	// between nodes and nodal boundary conditions (this is different from element loading, which is
 	// tightly coupled).
-	Neumann      []NodalValue // Only nodal Neumann BCs, no element loading
-	Dirichlet    []NodalValue
+	Neumann   []NodalValue // Only nodal Neumann BCs, no element loading
+	Dirichlet []NodalValue
+	// EqTransforms are applied in order before solving, and undone in reverse order afterwards. The
+	// first one hence acts on global coordinates, which linear constraints rely on.
 	EqTransforms []Transformer
+	// Settlements are prescribed support displacements or rotations that act as a load. They are
+	// added to the value of a Dirichlet BC for the same index, which must exist. This allows for
+	// declaring the supports once, and applying settlements as a separate load (case).
+	Settlements []NodalValue
 }
 
 // EquationSolver implements an algorithm to solve a linear system of equations with a symmetric
-// positive definite coefficient matrix. Examples: Cholesky or LU/QR decomposition, or an iterative
-// method.
+// coefficient matrix. The matrix is positive definite unless Dirichlet BCs are enforced with
+// Lagrange multipliers. Examples: Cholesky, LDLᵀ or LU decomposition, or an iterative method.
 type EquationSolver interface {
 	SolveLinearSystem(a mat.Symmetric, b, x *mat.VecDense) error
 }
 
+// Factorizer is implemented by an EquationSolver that decomposes the coefficient matrix, so that
+// the decomposition can be reused for multiple right-hand sides, e.g. load cases or positions of a
+// moving load, see [WithFactorizationReuse].
+type Factorizer interface {
+	Factorize(a mat.Symmetric) (Factorization, error)
+}
+
+// Factorization is a decomposed coefficient matrix a that solves a·x = b for any b.
+type Factorization interface {
+	Solve(b, x *mat.VecDense) error
+}
+
+// ContextEquationSolver is implemented by an EquationSolver that can abort early when the given
+// context is cancelled, e.g. an iterative method that checks for cancellation between iterations.
+type ContextEquationSolver interface {
+	SolveLinearSystemContext(ctx context.Context, a mat.Symmetric, b, x *mat.VecDense) error
+}
+
 // ProblemSolver solves the given boundary value problem and returns the complete set of primary
-// nodal values and reactions for nodes with Dirichlet BC.
+// nodal values and reactions for nodes with Dirichlet BC. Implementations are not required to be
+// safe for concurrent use, see [SolverPool] for that. The returned result stays valid when the
+// solver is used again.
 type ProblemSolver interface {
 	Solve(
 		p *Problem,
@@ -172,6 +219,18 @@ type ProblemSolver interface {
 	) (ProblemResult, error)
 }
 
+// ContextProblemSolver is implemented by a ProblemSolver that can be cancelled. SolveContext stops
+// early with an error that wraps the context's error when the context is cancelled. The solvers of
+// this package implement it, see [NewLinearProblemSolver] and [SolverPool].
+type ContextProblemSolver interface {
+	SolveContext(
+		ctx context.Context,
+		p *Problem,
+		idx EqLayout,
+		strategy EquationSolver,
+	) (ProblemResult, error)
+}
+
 // ProblemResult is the API to retrieve BVP results as needed. An implementation can choose to
 // evaluate individual results lazily or in batches, whatever makes most sense for the
 // representation of the data.
@@ -180,7 +239,53 @@ type ProblemResult interface {
 	Reaction(i Index) (NodalValue, error)
 	PrimaryAll() []NodalValue
 	ReactionAll() []NodalValue
+	// LocalReaction returns the reaction at the given index in the local frame of the
+	// [SkewedSupport] that rotates it, or the global reaction if there is none.
+	LocalReaction(i Index) (NodalValue, error)
 	Interpolate(elmtID string, quantity Fct, zeroTol float64) (Interpolation, error)
 	InterpolateAll(zeroTol float64) []Interpolation
+	// MaxAbs returns the value of the given quantity with the largest magnitude among all elements,
+	// e.g. the governing bending moment, see [Interpolation.Extrema]. The returned value is signed.
+	MaxAbs(quantity Fct, zeroTol float64) (Extremum, error)
+	// NormalStress returns the normal stress N/A + My·z/Iyy of the given element at the local z
+	// coordinate of its cross section.
+	NormalStress(elmtID string, z, zeroTol float64) (PolySequence, error)
+	// FibreStresses returns the normal stresses at the top and bottom fibres of the cross section,
+	// see [StressSection].
+	FibreStresses(elmtID string, zeroTol float64) (top, bottom PolySequence, err error)
+	// ShearStress returns the largest shear stress Vz/Avz of the cross section, see [StressSection].
+	ShearStress(elmtID string, zeroTol float64) (PolySequence, error)
+	// Utilization returns the largest ratio of the absolute fibre stress to the yield strength of
+	// the element's material. The Quantity of the result is FctUnknown.
+	Utilization(elmtID string, zeroTol float64) (Extremum, error)
+	// StrainEnergy returns the elastic strain energy ½·∫ N²/EA + My²/EIyy dx of the given element,
+	// or the sum over the condensed elements of a [Superelement].
+	StrainEnergy(elmtID string, zeroTol float64) (float64, error)
+	// Energy returns the total strain energy of all elements and the work of all loads, see
+	// [EnergyBalance].
+	Energy(zeroTol float64) (EnergyBalance, error)
+	// Equilibrium returns the sum of all loads and reactions, see [EquilibriumResidual]. The residual
+	// of the solution at free degrees of freedom contributes to the sum, too.
+	Equilibrium() (EquilibriumResidual, error)
+	// Warnings returns problems detected after solving, e.g. a violated equilibrium, see
+	// [WithEquilibriumTolerance].
+	Warnings() []string
+	// AtPosition returns the results at the relative position 0 ≤ ratio ≤ 1 along the given element
+	// in global coordinates, see [PointResult].
+	AtPosition(elmtID string, ratio, zeroTol float64) (PointResult, error)
+	// AtPoint returns the results of all elements whose axis passes the given global point within
+	// the distance tol, in the order of the problem's elements. At a node shared by several
+	// elements, each of them contributes a result, and their displacements are identical.
+	// Superelements contribute the results of their condensed elements, except for instances.
+	AtPoint(point r3.Vec, tol, zeroTol float64) ([]PointResult, error)
+	// DeformedShape returns the deformed axes of all elements with the displacements multiplied by
+	// scale. Each element is divided into the given number of equidistant segments, refined where
+	// its interpolations are piecewise. At the element ends, the deformed positions are exact.
+	// Superelements contribute the deformed shapes of their condensed elements, except for
+	// instances, whose elements aren't located at the nodes of the problem.
+	DeformedShape(scale float64, segments int, zeroTol float64) ([]DeformedElement, error)
+	// EndForces returns the forces that the nodes exert on the given element in local and global
+	// coordinates. With rigid end zones, they refer to the nodes, not the ends of the flexible part.
+	EndForces(elmtID string, zeroTol float64) (EndForces, error)
 	Dimension() (total, net int)
 }
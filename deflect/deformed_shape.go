@@ -0,0 +1,125 @@
+package deflect
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"gonum.org/v1/gonum/floats/scalar"
+	"gonum.org/v1/gonum/spatial/r3"
+)
+
+// DeformedElement is the deformed axis of an element, sampled as a polyline in global coordinates,
+// see [ProblemResult.DeformedShape].
+type DeformedElement struct {
+	Element string
+	// X holds the local positions of the samples, Points the deformed positions in global
+	// coordinates. Both have the same length.
+	X      []float64
+	Points []r3.Vec
+}
+
+func (sr *solverResult) DeformedShape(
+	scale float64,
+	segments int,
+	zeroTol float64,
+) ([]DeformedElement, error) {
+	if segments < 1 {
+		return nil, fmt.Errorf("at least one segment per element required, got %v", segments)
+	}
+
+	result := make([]DeformedElement, 0, len(sr.elements))
+	var err error
+
+	for _, e := range sr.elements {
+		if s, ok := e.(*Superelement); ok {
+			condensed, errCondensed := sr.deformedCondensed(s, scale, segments, zeroTol)
+			result = append(result, condensed...)
+			err = errors.Join(err, errCondensed)
+			continue
+		}
+
+		geometry, ok := e.(lineGeometry)
+		if !ok {
+			err = errors.Join(err, fmt.Errorf("element %v has no straight axis to sample", e.ID()))
+			continue
+		}
+
+		deformed, errSingle := sr.deformedElement(e, geometry, scale, segments, zeroTol)
+		err = errors.Join(err, errSingle)
+
+		if errSingle == nil {
+			result = append(result, deformed)
+		}
+	}
+
+	return result, err
+}
+
+// deformedCondensed returns the deformed shapes of the elements that s condenses.
+func (sr *solverResult) deformedCondensed(
+	s *Superelement,
+	scale float64,
+	segments int,
+	zeroTol float64,
+) ([]DeformedElement, error) {
+	if s.rename != nil {
+		// The recovered elements are located at the original nodes, not at the ones of the instance.
+		return nil, fmt.Errorf("can't sample the deformed shape of instance %v", s.ID())
+	}
+
+	recovered, err := s.Recover(sr)
+	if err != nil {
+		return nil, err
+	}
+
+	return recovered.DeformedShape(scale, segments, zeroTol)
+}
+
+// deformedElement samples elmt at equidistant positions plus the boundaries of its piecewise
+// displacement interpolations, where the deformed axis has kinks or changes its curvature.
+func (sr *solverResult) deformedElement(
+	elmt Element,
+	geometry lineGeometry,
+	scale float64,
+	segments int,
+	zeroTol float64,
+) (DeformedElement, error) {
+	fields, err := sr.localFieldsOf(elmt, geometry, zeroTol)
+	if err != nil {
+		return DeformedElement{}, err
+	}
+
+	l := geometry.loadedLength()
+	x := make([]float64, 0, segments+1)
+
+	for i := range segments + 1 {
+		x = append(x, float64(i)*l/float64(segments))
+	}
+
+	for _, fct := range [...]Fct{FctUx, FctUz} {
+		for _, piece := range fields.interpolations[fct] {
+			x = append(x, piece.X0)
+		}
+	}
+
+	slices.Sort(x)
+	x = slices.CompactFunc(x, func(a, b float64) bool {
+		return scalar.EqualWithinAbsOrRel(a, b, 1e-10, 1e-10)
+	})
+	// The last sample must be the exact element end to get the nodal displacement there:
+	x[len(x)-1] = l
+
+	result := DeformedElement{Element: elmt.ID(), X: x, Points: make([]r3.Vec, len(x))}
+
+	for i, xi := range x {
+		point, err := pointResultOf(elmt, geometry, fields, xi)
+		if err != nil {
+			return DeformedElement{}, err
+		}
+
+		result.Points[i] = r3.Add(point.Position, r3.Scale(scale, point.Displacement))
+	}
+
+	return result, nil
+}
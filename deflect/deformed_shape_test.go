@@ -0,0 +1,153 @@
+package deflect
+
+import (
+	"slices"
+	"testing"
+
+	"gonum.org/v1/gonum/spatial/r3"
+)
+
+func TestDeformedShapeWithHinge(t *testing.T) {
+	nodes := []Node{{ID: "A"}, {ID: "C", X: 2}, {ID: "B", X: 4}}
+	hinge := map[Index]struct{}{{NodalID: "C", Dof: Phiy}: {}}
+	ac, errAC := NewFrame2d("AC", &nodes[0], &nodes[1], &exampleMat, map[Index]struct{}{})
+	cb, errCB := NewFrame2d("CB", &nodes[1], &nodes[2], &exampleMat, hinge)
+	load, errLoad := NewElementConcentratedLoad(Uz, 0.7, 5e3)
+
+	if errAC != nil || errCB != nil || errLoad != nil {
+		t.Fatalf("Expected successful frame and load instantiation, got %v, %v, %v",
+			errAC, errCB, errLoad)
+	}
+
+	ac.AddLoad(load)
+	cb.AddLoad(NewElementConstantLoad(Uz, 1e3))
+
+	// A cantilever with a hinged, propped extension.
+	p := Problem{
+		Nodes:    nodes,
+		Elements: []Element{ac, cb},
+		Dirichlet: []NodalValue{
+			{Index: Index{NodalID: "A", Dof: Ux}, Value: 0},
+			{Index: Index{NodalID: "A", Dof: Uz}, Value: 0},
+			{Index: Index{NodalID: "A", Dof: Phiy}, Value: 0},
+			{Index: Index{NodalID: "B", Dof: Uz}, Value: 0},
+		},
+		Neumann: []NodalValue{{Index: Index{NodalID: "B", Dof: Ux}, Value: 2e3}},
+	}
+	result := solveTestProblem(t, &p)
+	const scale, segments = 100.0, 4
+
+	shapes, err := result.DeformedShape(scale, segments, 1e-10)
+
+	if err != nil {
+		t.Fatalf("Expected deformed shape, got %v", err)
+	} else if len(shapes) != 2 || shapes[0].Element != "AC" || shapes[1].Element != "CB" {
+		t.Fatalf("Expected deformed shapes of AC and CB, got %+v", shapes)
+	}
+
+	if x := []float64{0, 0.5, 0.7, 1, 1.5, 2}; !slices.Equal(shapes[0].X, x) {
+		t.Errorf("Expected samples %v including the load position, got %v", x, shapes[0].X)
+	}
+
+	if n := len(shapes[1].X); n != segments+1 || len(shapes[1].Points) != n {
+		t.Errorf("Expected %v samples of CB, got %v", segments+1, shapes[1])
+	}
+
+	deformedNode := func(nodalID string, position r3.Vec) r3.Vec {
+		ux, errUx := result.Primary(Index{NodalID: nodalID, Dof: Ux})
+		uz, errUz := result.Primary(Index{NodalID: nodalID, Dof: Uz})
+
+		if errUx != nil || errUz != nil {
+			t.Fatalf("Expected displacements of %v, got %v, %v", nodalID, errUx, errUz)
+		}
+
+		return r3.Add(position, r3.Scale(scale, r3.Vec{X: ux.Value, Z: uz.Value}))
+	}
+
+	// Both elements meet exactly at the hinge, despite their different end rotations.
+	atHinge := deformedNode("C", r3.Vec{X: 2})
+
+	end, start := shapes[0].Points[len(shapes[0].Points)-1], shapes[1].Points[0]
+
+	if end != atHinge || start != atHinge {
+		t.Errorf("Expected both elements at %v, got %v and %v", atHinge, end, start)
+	}
+
+	start, end = shapes[0].Points[0], shapes[1].Points[segments]
+
+	if start != (r3.Vec{}) || end != deformedNode("B", r3.Vec{X: 4}) {
+		t.Errorf("Expected exact deformed positions of A and B, got %v and %v", start, end)
+	}
+
+	middle, err := result.AtPosition("CB", 0.5, 1e-10)
+	if err != nil {
+		t.Fatalf("Expected result in the middle of CB, got %v", err)
+	}
+
+	expected := r3.Add(middle.Position, r3.Scale(scale, middle.Displacement))
+
+	if !vecEqualWithinAbs(shapes[1].Points[2], expected, 1e-12) {
+		t.Errorf("Expected deformed position %v, got %v", expected, shapes[1].Points[2])
+	}
+
+	if _, err := result.DeformedShape(scale, 0, 1e-10); err == nil {
+		t.Errorf("Expected error for zero segments, got nil")
+	}
+}
+
+func TestDeformedShapeWithSuperelement(t *testing.T) {
+	nodes := []Node{{ID: "N0"}, {ID: "N1", X: 1, Z: 0.2}, {ID: "N2", X: 2}}
+	full := Problem{
+		Nodes:     nodes,
+		Elements:  continuousBeamFrames(t, nodes),
+		Dirichlet: superelementTestSupports("N2"),
+	}
+	expected, err := solveTestProblem(t, &full).DeformedShape(100, 3, 1e-10)
+	if err != nil {
+		t.Fatalf("Expected deformed shape, got %v", err)
+	}
+
+	s, err := NewSuperelement("S", continuousBeamFrames(t, nodes), []string{"N0", "N2"})
+	if err != nil {
+		t.Fatalf("Expected successful condensation, got %v", err)
+	}
+
+	condensed := Problem{
+		Nodes:     []Node{nodes[0], nodes[2]},
+		Elements:  []Element{s},
+		Dirichlet: superelementTestSupports("N2"),
+	}
+	actual, err := solveTestProblem(t, &condensed).DeformedShape(100, 3, 1e-10)
+
+	if err != nil {
+		t.Fatalf("Expected deformed shape of the condensed elements, got %v", err)
+	} else if len(actual) != len(expected) {
+		t.Fatalf("Expected %v deformed elements, got %+v", len(expected), actual)
+	}
+
+	for i, want := range expected {
+		got := actual[i]
+
+		if got.Element != want.Element || !slices.Equal(got.X, want.X) {
+			t.Fatalf("Expected samples %v of %v, got %v of %v", want.X, want.Element, got.X, got.Element)
+		}
+
+		for j := range want.Points {
+			if !vecEqualWithinAbs(got.Points[j], want.Points[j], 1e-10) {
+				t.Errorf("Expected %v at %v of %v, got %v", want.Points[j], want.X[j], want.Element,
+					got.Points[j])
+			}
+		}
+	}
+
+	instance, err := s.Instance("S1", map[string]string{"N0": "N0", "N2": "N2"})
+	if err != nil {
+		t.Fatalf("Expected successful instantiation, got %v", err)
+	}
+
+	condensed.Elements = []Element{instance}
+
+	if _, err := solveTestProblem(t, &condensed).DeformedShape(100, 3, 1e-10); err == nil {
+		t.Errorf("Expected error for superelement instance, got nil")
+	}
+}
@@ -9,6 +9,14 @@ func TestDofStringer(t *testing.T) {
 	}
 }
 
+func TestFctStringer(t *testing.T) {
+	for fct, expect := range map[Fct]string{FctMx: "Mx", FctEpsx: "Epsx", FctKappay: "Kappay"} {
+		if actual := fct.String(); actual != expect {
+			t.Errorf("Expected Fct's String() to be '%v', got '%v'", expect, actual)
+		}
+	}
+}
+
 func TestDofSortOrder(t *testing.T) {
 	if !(Ux < Uz) {
 		t.Errorf("Intuitive ordering Ux < Uz not satisfied")
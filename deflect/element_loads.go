@@ -1,6 +1,12 @@
 package deflect
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"gonum.org/v1/gonum/floats/scalar"
+)
 
 type neumannConcentrated struct {
 	kind            Dof
@@ -31,12 +37,280 @@ func NewElementConstantLoad(kind Dof, value float64) NeumannElementBC {
 type neumannLinear struct {
 	kind        Dof
 	first, last float64
+	// The load acts on [from, to], where to is +∞ when the load spans the whole element.
+	from, to float64
 }
 
 // NewElementLinearLoad instantiates an element Neumann boundary condition that applies a
-// distributed, linear load.
+// distributed, linear load over the whole element.
 func NewElementLinearLoad(kind Dof, first, last float64) NeumannElementBC {
-	return &neumannLinear{kind: kind, first: first, last: last}
+	return &neumannLinear{kind: kind, first: first, last: last, from: 0, to: math.Inf(1)}
+}
+
+// NewElementPartialLinearLoad instantiates an element Neumann boundary condition that applies a
+// distributed, linear load over [from, to], with the values first at from and last at to. A
+// constant partial load is given by identical first and last values. Any part of the load beyond
+// the element length is ignored, and [Element.AddLoad] rejects a load that starts beyond it.
+func NewElementPartialLinearLoad(
+	kind Dof,
+	from, to, first, last float64,
+) (NeumannElementBC, error) {
+	if from < 0 || to <= from {
+		return nil, fmt.Errorf("can't instantiate partial element load over [%v, %v]", from, to)
+	}
+
+	return &neumannLinear{kind: kind, first: first, last: last, from: from, to: to}, nil
+}
+
+// piece returns the load as a polynomial over its domain, given the element length l.
+func (n *neumannLinear) piece(l float64) PolyPiece {
+	a, b := n.from, min(n.to, l)
+	end := n.last
+
+	if n.to > l && !math.IsInf(n.to, 1) {
+		// Truncated, so the value at the element end is interpolated.
+		end = n.first + (n.last-n.first)*(b-a)/(n.to-a)
+	}
+
+	slope := (end - n.first) / (b - a)
+
+	return PolyPiece{X0: a, XE: b, Coeff: []float64{n.first - slope*a, slope}}
+}
+
+type neumannPolynomial struct {
+	kind Dof
+	// Coefficients in the element coordinate x, i.e., not relative to from.
+	coeff []float64
+	// The load acts on [from, to], where to is +∞ when the load spans the whole element.
+	from, to float64
+}
+
+// NewElementPolynomialLoad instantiates an element Neumann boundary condition that applies a
+// distributed load q(x) = Σ coeff[i]·xⁱ over the whole element, where x is the element coordinate.
+func NewElementPolynomialLoad(kind Dof, coeff []float64) (NeumannElementBC, error) {
+	return newElementPolynomialLoad(kind, 0, math.Inf(1), coeff)
+}
+
+// NewElementPartialPolynomialLoad is like [NewElementPolynomialLoad], but the load acts on [from,
+// to] only. The coefficients still refer to the element coordinate x, not to x - from. Any part of
+// the load beyond the element length is ignored, and [Element.AddLoad] rejects a load that starts
+// beyond it.
+func NewElementPartialPolynomialLoad(
+	kind Dof,
+	from, to float64,
+	coeff []float64,
+) (NeumannElementBC, error) {
+	return newElementPolynomialLoad(kind, from, to, coeff)
+}
+
+func newElementPolynomialLoad(
+	kind Dof,
+	from, to float64,
+	coeff []float64,
+) (NeumannElementBC, error) {
+	if len(coeff) == 0 {
+		return nil, fmt.Errorf("can't instantiate polynomial element load without coefficients")
+	} else if from < 0 || to <= from {
+		return nil, fmt.Errorf("can't instantiate polynomial element load over [%v, %v]", from, to)
+	}
+
+	n := len(coeff)
+	for n > 1 && coeff[n-1] == 0 {
+		n--
+	}
+
+	return &neumannPolynomial{kind: kind, coeff: slices.Clone(coeff[:n]), from: from, to: to}, nil
+}
+
+// piece returns the load as a polynomial over its domain, given the element length l.
+func (n *neumannPolynomial) piece(l float64) PolyPiece {
+	return PolyPiece{X0: n.from, XE: min(n.to, l), Coeff: slices.Clone(n.coeff)}
+}
+
+// consistentLoads returns the integrals ∫ q·N dx of the distributed load q with each of the given
+// shape functions N.
+func consistentLoads(q PolyPiece, shapes ...[]float64) []float64 {
+	result := make([]float64, len(shapes))
+
+	for i, shape := range shapes {
+		qN := q.product(&PolyPiece{Coeff: shape})
+		result[i] = qN.definiteIntegral()
+	}
+
+	return result
+}
+
+// loadIntegrals returns the polynomials -∫q dx and -∫∫q dx dx of the distributed load q as seen
+// from the element start, e.g. the contribution of the load to the normal force and the bending
+// moment. Both are zero before the load starts, so only the domain over the load and the one after
+// it up to the element length l are returned.
+func loadIntegrals(q PolyPiece, l float64) (first, second PolySequence) {
+	q1 := q.integrate(0)
+	q2 := q1.integrate(0)
+	b := q.XE
+	q1b, _ := q1.Eval(b)
+	q2b, _ := q2.Eval(b)
+
+	first = PolySequence{q1}
+	second = PolySequence{q2}
+
+	if b < l && !scalar.EqualWithinAbs(b, l, 1e-10) {
+		first = append(first, PolyPiece{X0: b, XE: l, Coeff: []float64{q1b}})
+		second = append(second, PolyPiece{X0: b, XE: l, Coeff: []float64{q2b - q1b*b, q1b}})
+	}
+
+	first.multiply(-1)
+	second.multiply(-1)
+
+	return first, second
+}
+
+type neumannInitialStrain struct {
+	strain, misfit, prestress float64
+}
+
+// NewElementInitialStrain instantiates an element load that imposes a constant axial strain ε₀, so
+// that the normal force is N = EA·(u' - ε₀). A positive strain lets an unrestrained member
+// elongate, while it causes compression in a restrained one.
+func NewElementInitialStrain(strain float64) NeumannElementBC {
+	return &neumannInitialStrain{strain: strain}
+}
+
+// NewElementLengthMisfit instantiates an element load for a member that is fabricated too long
+// (positive misfit) or too short (negative misfit) by the given length. This is identical to an
+// initial strain of misfit/l.
+func NewElementLengthMisfit(misfit float64) NeumannElementBC {
+	return &neumannInitialStrain{misfit: misfit}
+}
+
+// NewElementPrestress instantiates an element load that prestresses the member with the given
+// axial force, e.g. a tendon-stressed tie. It is the initial strain -force/EA, i.e., the given
+// force results as a tensile normal force (positive force) in a fully restrained member.
+func NewElementPrestress(force float64) NeumannElementBC {
+	return &neumannInitialStrain{prestress: force}
+}
+
+// initialStrain returns the imposed axial strain ε₀ for the given element length and axial
+// stiffness.
+func (n *neumannInitialStrain) initialStrain(l, EA float64) float64 {
+	return n.strain + n.misfit/l - n.prestress/EA
+}
+
+type neumannGlobal struct {
+	// The kind of the wrapped load is a global direction.
+	load      NeumannElementBC
+	projected bool
+}
+
+// NewElementGlobalLoad wraps the given concentrated or distributed load, so that its kind (Ux or
+// Uz) refers to the global instead of the local element direction, e.g. a vertical load on an
+// inclined member. Elements decompose such a load into its local axial and transverse parts.
+func NewElementGlobalLoad(load NeumannElementBC) (NeumannElementBC, error) {
+	return newElementGlobalLoad(load, false)
+}
+
+// NewElementProjectedLoad is like [NewElementGlobalLoad], but for distributed loads that are given
+// per length of the element's projection perpendicular to the load direction, e.g. snow on an
+// inclined rafter per horizontal length. Positions still refer to the element axis.
+func NewElementProjectedLoad(load NeumannElementBC) (NeumannElementBC, error) {
+	return newElementGlobalLoad(load, true)
+}
+
+func newElementGlobalLoad(load NeumannElementBC, projected bool) (NeumannElementBC, error) {
+	var kind Dof
+	var err error
+
+	if _, nested := load.(*neumannGlobal); nested {
+		return nil, fmt.Errorf("can't wrap a global load into another one")
+	}
+
+	loadDispatch(load,
+		func(l *neumannConcentrated) {
+			kind = l.kind
+			if projected {
+				err = fmt.Errorf("concentrated loads can't be projected")
+			}
+		},
+		func(l *neumannConstant) { kind = l.kind },
+		func(l *neumannLinear) { kind = l.kind },
+		func(l *neumannPolynomial) { kind = l.kind },
+		func(*neumannInitialStrain) { err = fmt.Errorf("initial strains have no global direction") })
+
+	if err == nil && kind != Ux && kind != Uz {
+		err = fmt.Errorf("global element loads must act in Ux or Uz direction, not %v", kind)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &neumannGlobal{load: load, projected: projected}, nil
+}
+
+// local decomposes the load into its local parts for a 2d element with the given direction sine
+// and cosine. The axial part is of kind Ux, the transverse part of kind Uz, and either is nil when
+// the load has no component in that direction.
+func (n *neumannGlobal) local(s, c float64) (axial, transverse NeumannElementBC) {
+	var kind Dof
+
+	loadDispatch(n.load,
+		func(l *neumannConcentrated) { kind = l.kind },
+		func(l *neumannConstant) { kind = l.kind },
+		func(l *neumannLinear) { kind = l.kind },
+		func(l *neumannPolynomial) { kind = l.kind },
+		func(*neumannInitialStrain) {})
+
+	// The local x-axis is (c, s) and the local z-axis is (s, -c) in global coordinates.
+	toAxial, toTransverse, projection := s, -c, math.Abs(c)
+
+	if kind == Ux {
+		toAxial, toTransverse, projection = c, s, math.Abs(s)
+	}
+
+	if !n.projected {
+		projection = 1
+	}
+
+	if !scalar.EqualWithinAbs(toAxial*projection, 0, 1e-12) {
+		axial = scaledLoad(n.load, Ux, toAxial*projection)
+	}
+
+	if !scalar.EqualWithinAbs(toTransverse*projection, 0, 1e-12) {
+		transverse = scaledLoad(n.load, Uz, toTransverse*projection)
+	}
+
+	return axial, transverse
+}
+
+// scaledLoad returns a copy of the given load with the given kind and values multiplied by factor.
+func scaledLoad(bc NeumannElementBC, kind Dof, factor float64) NeumannElementBC {
+	var result NeumannElementBC
+
+	loadDispatch(bc,
+		func(l *neumannConcentrated) {
+			result = &neumannConcentrated{kind: kind, position: l.position, value: factor * l.value}
+		},
+		func(l *neumannConstant) {
+			result = &neumannConstant{kind: kind, value: factor * l.value}
+		},
+		func(l *neumannLinear) {
+			scaled := *l
+			scaled.kind, scaled.first, scaled.last = kind, factor*l.first, factor*l.last
+			result = &scaled
+		},
+		func(l *neumannPolynomial) {
+			scaled := *l
+			scaled.kind, scaled.coeff = kind, slices.Clone(l.coeff)
+
+			for i := range scaled.coeff {
+				scaled.coeff[i] *= factor
+			}
+
+			result = &scaled
+		},
+		func(l *neumannInitialStrain) { result = l })
+
+	return result
 }
 
 // loadDispatch implements an exhaustive type switch over all NeumannElementBC types and calls the
@@ -53,11 +327,16 @@ func NewElementLinearLoad(kind Dof, first, last float64) NeumannElementBC {
 // than this approach.
 // - Distinct struct with callback fields. This has no exhaustiveness guarantee at compile time,
 // since it still allows default initialisation of callbacks, which are then unusable at runtime.
+//
+// Loads in global coordinates are not part of the dispatch. Elements decompose them into local
+// loads in AddLoad, so they are never stored.
 func loadDispatch(
 	bc NeumannElementBC,
 	concentrated func(*neumannConcentrated),
 	constant func(*neumannConstant),
 	linear func(*neumannLinear),
+	polynomial func(*neumannPolynomial),
+	initialStrain func(*neumannInitialStrain),
 ) {
 	switch load := bc.(type) {
 	case *neumannConcentrated:
@@ -66,5 +345,9 @@ func loadDispatch(
 		constant(load)
 	case *neumannLinear:
 		linear(load)
+	case *neumannPolynomial:
+		polynomial(load)
+	case *neumannInitialStrain:
+		initialStrain(load)
 	}
 }
@@ -1,6 +1,11 @@
 package deflect
 
-import "testing"
+import (
+	"slices"
+	"testing"
+
+	"gonum.org/v1/gonum/floats/scalar"
+)
 
 func TestConcentratedLoadConstruction(t *testing.T) {
 	_, err := NewElementConcentratedLoad(Ux, -0.5, 123.0)
@@ -15,3 +20,205 @@ func TestConcentratedLoadConstruction(t *testing.T) {
 		t.Errorf("BC construction failed despite given correct parameters")
 	}
 }
+
+func TestInitialStrainVariants(t *testing.T) {
+	l, EA := 2.0, 1000.0
+	cases := []struct {
+		load     NeumannElementBC
+		expected float64
+	}{
+		{NewElementInitialStrain(1e-3), 1e-3},
+		{NewElementLengthMisfit(-0.004), -0.002},
+		{NewElementPrestress(5), -0.005},
+	}
+
+	for _, c := range cases {
+		actual := c.load.(*neumannInitialStrain).initialStrain(l, EA)
+
+		if actual != c.expected {
+			t.Errorf("Expected initial strain %v, got %v", c.expected, actual)
+		}
+	}
+}
+
+func TestPartialLinearLoadConstruction(t *testing.T) {
+	invalid := [][2]float64{{-1, 2}, {2, 2}, {3, 1}}
+
+	for _, interval := range invalid {
+		if _, err := NewElementPartialLinearLoad(Uz, interval[0], interval[1], 1, 2); err == nil {
+			t.Errorf("Expected failure for partial load over %v", interval)
+		}
+	}
+
+	if _, err := NewElementPartialLinearLoad(Uz, 0.5, 1.5, 1, 2); err != nil {
+		t.Errorf("Expected partial load construction to succeed, got %v", err)
+	}
+}
+
+func TestPartialLinearLoadTruncation(t *testing.T) {
+	// The load over [1, 3] is cut at the element end l = 2, where its value is 2.
+	load, _ := NewElementPartialLinearLoad(Uz, 1, 3, 1, 3)
+	piece := load.(*neumannLinear).piece(2)
+
+	if piece.X0 != 1 || piece.XE != 2 || !slices.Equal(piece.Coeff, []float64{0, 1}) {
+		t.Errorf("Expected truncated load 0 + x over [1, 2], got %v", piece)
+	}
+}
+
+func TestPartialLoadBeyondElement(t *testing.T) {
+	nodes := []Node{{ID: "A"}, {ID: "B", X: 2}}
+	frame, _ := NewFrame2d("AB", &nodes[0], &nodes[1], &exampleMat, map[Index]struct{}{})
+
+	for _, from := range []float64{2, 2.5} {
+		linear, _ := NewElementPartialLinearLoad(Uz, from, 3, 1, 2)
+		polynomial, _ := NewElementPartialPolynomialLoad(Ux, from, 3, []float64{1, 2})
+
+		if frame.AddLoad(linear) || frame.AddLoad(polynomial) {
+			t.Errorf("Expected partial loads starting at x = %v to be rejected", from)
+		}
+	}
+
+	load, _ := NewElementPartialLinearLoad(Uz, 1.5, 3, 1, 2)
+
+	if !frame.AddLoad(load) {
+		t.Errorf("Expected truncated partial load to be accepted")
+	}
+}
+
+func TestGlobalLoadConstruction(t *testing.T) {
+	concentrated, _ := NewElementConcentratedLoad(Uz, 1, 10)
+	moment, _ := NewElementConcentratedLoad(Phiy, 1, 10)
+	global, _ := NewElementGlobalLoad(NewElementConstantLoad(Uz, 1))
+	cases := []struct {
+		load      NeumannElementBC
+		projected bool
+		fails     bool
+	}{
+		{NewElementConstantLoad(Ux, 1), false, false},
+		{NewElementLinearLoad(Uz, 1, 2), true, false},
+		{concentrated, false, false},
+		{concentrated, true, true},
+		{moment, false, true},
+		{NewElementConstantLoad(Uy, 1), false, true},
+		{NewElementInitialStrain(1e-3), false, true},
+		{global, false, true},
+	}
+
+	for i, c := range cases {
+		construct := NewElementGlobalLoad
+		if c.projected {
+			construct = NewElementProjectedLoad
+		}
+
+		if _, err := construct(c.load); (err != nil) != c.fails {
+			t.Errorf("Case %v: expected failure %v, got error %v", i, c.fails, err)
+		}
+	}
+}
+
+func TestGlobalLoadDecomposition(t *testing.T) {
+	// Direction of the element is (0.8, 0.6), so that a vertical load of -10 per true length has
+	// the axial part -6 and the transverse part 8.
+	s, c := 0.6, 0.8
+	global, _ := NewElementGlobalLoad(NewElementConstantLoad(Uz, -10))
+	projected, _ := NewElementProjectedLoad(NewElementConstantLoad(Uz, -10))
+	horizontal, _ := NewElementGlobalLoad(NewElementConstantLoad(Ux, 5))
+
+	cases := []struct {
+		load              NeumannElementBC
+		axial, transverse float64
+	}{
+		{global, -6, 8},
+		{projected, -4.8, 6.4},
+		{horizontal, 4, 3},
+	}
+
+	for _, test := range cases {
+		axial, transverse := test.load.(*neumannGlobal).local(s, c)
+		actualAxial, actualTransverse := axial.(*neumannConstant), transverse.(*neumannConstant)
+
+		if actualAxial.kind != Ux || !scalar.EqualWithinAbs(actualAxial.value, test.axial, 1e-12) {
+			t.Errorf("Expected axial part %v, got %v", test.axial, actualAxial)
+		}
+
+		if actualTransverse.kind != Uz ||
+			!scalar.EqualWithinAbs(actualTransverse.value, test.transverse, 1e-12) {
+			t.Errorf("Expected transverse part %v, got %v", test.transverse, actualTransverse)
+		}
+	}
+
+	if axial, _ := global.(*neumannGlobal).local(1, 0); axial == nil {
+		t.Errorf("Expected axial part for vertical element")
+	} else if _, transverse := global.(*neumannGlobal).local(1, 0); transverse != nil {
+		t.Errorf("Expected no transverse part for vertical element, got %v", transverse)
+	}
+}
+
+func TestGlobalLoadRemoval(t *testing.T) {
+	nodes := []Node{{ID: "A"}, {ID: "B", X: 4, Z: 3}, {ID: "C", Z: 3}}
+	frame, _ := NewFrame2d("AB", &nodes[0], &nodes[1], &exampleMat, map[Index]struct{}{})
+	inclined, _ := NewTruss2d("AB", &nodes[0], &nodes[1], &exampleMat, map[Index]struct{}{})
+	vertical, _ := NewTruss2d("AC", &nodes[0], &nodes[2], &exampleMat, map[Index]struct{}{})
+	global, _ := NewElementGlobalLoad(NewElementConstantLoad(Uz, -10))
+	partial, _ := NewElementPartialLinearLoad(Uz, 6, 7, 1, 1)
+	beyond, _ := NewElementGlobalLoad(partial)
+	numLoads := func(elmt Element) int {
+		return len(elmt.(interface{ elementLoads() []NeumannElementBC }).elementLoads())
+	}
+
+	cases := []struct {
+		name     string
+		elmt     Element
+		load     NeumannElementBC
+		accepted bool
+		// Number of local parts per added global load:
+		parts int
+	}{
+		{"frame", frame, global, true, 2},
+		{"inclined truss", inclined, global, false, 0},
+		{"vertical truss", vertical, global, true, 1},
+		{"frame, beyond element", frame, beyond, false, 0},
+	}
+
+	for _, c := range cases {
+		for range 2 {
+			if accepted := c.elmt.AddLoad(c.load); accepted != c.accepted {
+				t.Errorf("%v: expected AddLoad to return %v, got %v", c.name, c.accepted, accepted)
+			}
+		}
+
+		if n := numLoads(c.elmt); n != 2*c.parts {
+			t.Errorf("%v: expected %v local loads, got %v", c.name, 2*c.parts, n)
+		}
+
+		c.elmt.RemoveLoad(c.load)
+
+		if n := numLoads(c.elmt); n != 0 {
+			t.Errorf("%v: expected no loads after removal, got %v", c.name, n)
+		}
+	}
+}
+
+func TestPolynomialLoadConstruction(t *testing.T) {
+	if _, err := NewElementPolynomialLoad(Uz, nil); err == nil {
+		t.Errorf("Expected failure for polynomial load without coefficients")
+	}
+
+	if _, err := NewElementPartialPolynomialLoad(Uz, 2, 1, []float64{1}); err == nil {
+		t.Errorf("Expected failure for polynomial load over invalid interval")
+	}
+
+	coeff := []float64{1, 2, 0, 0}
+	load, err := NewElementPartialPolynomialLoad(Uz, 1, 3, coeff)
+
+	if err != nil {
+		t.Fatalf("Expected polynomial load construction to succeed, got %v", err)
+	}
+
+	coeff[0] = 100 // Must not affect the load
+	piece := load.(*neumannPolynomial).piece(2)
+
+	if piece.X0 != 1 || piece.XE != 2 || !slices.Equal(piece.Coeff, []float64{1, 2}) {
+		t.Errorf("Expected trimmed, truncated load 1 + 2x over [1, 2], got %v", piece)
+	}
+}
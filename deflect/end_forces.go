@@ -0,0 +1,86 @@
+package deflect
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"gonum.org/v1/gonum/spatial/r3"
+)
+
+// EndForce is the force and moment that a node exerts on an element end. N and V are the
+// components along the local x- and z-axis, and M is the moment about the local y-axis. Force and
+// Moment are the same quantities in global coordinates.
+type EndForce struct {
+	N, V, M       float64
+	Force, Moment r3.Vec
+}
+
+// EndForces holds the end forces of an element, see [ProblemResult.EndForces]. They include the
+// effect of element loads and vanish for released degrees of freedom at hinges.
+type EndForces struct {
+	Element    string
+	Start, End EndForce
+}
+
+func (sr *solverResult) EndForces(elmtID string, zeroTol float64) (EndForces, error) {
+	idx := slices.IndexFunc(sr.elements, func(e Element) bool { return e.ID() == elmtID })
+
+	if idx == -1 {
+		return EndForces{}, fmt.Errorf("no element with ID '%v' found", elmtID)
+	}
+
+	elmt := sr.elements[idx]
+	geometry, ok := elmt.(lineGeometry)
+
+	if !ok {
+		return EndForces{}, fmt.Errorf("can't determine the end forces of element %v", elmtID)
+	}
+
+	fields, err := sr.localFieldsOf(elmt, geometry, zeroTol)
+	if err != nil {
+		return EndForces{}, err
+	}
+
+	start, errStart := pointResultOf(elmt, geometry, fields, 0)
+	end, errEnd := pointResultOf(elmt, geometry, fields, geometry.loadedLength())
+
+	if err := errors.Join(errStart, errEnd); err != nil {
+		return EndForces{}, err
+	}
+
+	n0, n1 := geometry.endNodes()
+
+	// The section forces act on the positive cut face, so the sign flips at the start.
+	return EndForces{
+		Element: elmtID,
+		Start:   sr.endForce(geometry, start, -1, n0.ID),
+		End:     sr.endForce(geometry, end, 1, n1.ID),
+	}, nil
+}
+
+// endForce returns the section forces of pr multiplied by sign, moved to the node with the given
+// ID. Only rigid end zones separate the node from pr, and their lever adds to the moment.
+func (sr *solverResult) endForce(
+	geometry lineGeometry,
+	pr PointResult,
+	sign float64,
+	nodalID string,
+) EndForce {
+	force, moment := r3.Scale(sign, pr.Force), r3.Scale(sign, pr.Moment)
+
+	if i := slices.IndexFunc(sr.nodes, func(n Node) bool { return n.ID == nodalID }); i != -1 {
+		node := r3.Vec{X: sr.nodes[i].X, Y: sr.nodes[i].Y, Z: sr.nodes[i].Z}
+		moment = r3.Add(moment, r3.Cross(r3.Sub(pr.Position, node), force))
+	}
+
+	_, ex, ez := geometry.axes()
+
+	return EndForce{
+		N:      r3.Dot(force, ex),
+		V:      r3.Dot(force, ez),
+		M:      r3.Dot(moment, r3.Cross(ez, ex)),
+		Force:  force,
+		Moment: moment,
+	}
+}
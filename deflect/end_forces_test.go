@@ -0,0 +1,152 @@
+package deflect
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/floats/scalar"
+	"gonum.org/v1/gonum/spatial/r3"
+)
+
+// proppedCantileverTestProblem returns a beam of length 4 with fixed support A, a roller at B, and
+// a constant load of 1e3 in local z direction.
+func proppedCantileverTestProblem(t *testing.T, offsets [2]r3.Vec) Problem {
+	t.Helper()
+
+	nodes := []Node{{ID: "A"}, {ID: "B", X: 4}}
+	hinges := map[Index]struct{}{}
+	elmt, err := NewOffsetFrame2d("AB", &nodes[0], &nodes[1], &exampleMat, hinges, offsets)
+
+	if err != nil {
+		t.Fatalf("Expected successful frame instantiation, got %v", err)
+	}
+
+	elmt.AddLoad(NewElementConstantLoad(Uz, 1e3))
+
+	return Problem{
+		Nodes:    nodes,
+		Elements: []Element{elmt},
+		Dirichlet: []NodalValue{
+			{Index: Index{NodalID: "A", Dof: Ux}, Value: 0},
+			{Index: Index{NodalID: "A", Dof: Uz}, Value: 0},
+			{Index: Index{NodalID: "A", Dof: Phiy}, Value: 0},
+			{Index: Index{NodalID: "B", Dof: Uz}, Value: 0},
+		},
+	}
+}
+
+func TestEndForcesBalanceReactions(t *testing.T) {
+	cases := []struct {
+		name    string
+		offsets [2]r3.Vec
+		// Length of the flexible part, which carries the load:
+		l float64
+	}{
+		{name: "plain", l: 4},
+		{name: "rigid zone", offsets: [2]r3.Vec{{X: 0.5}}, l: 3.5},
+	}
+
+	for _, c := range cases {
+		p := proppedCantileverTestProblem(t, c.offsets)
+		result := solveTestProblem(t, &p)
+		forces, err := result.EndForces("AB", 1e-10)
+
+		if err != nil {
+			t.Fatalf("%v: expected end forces, got %v", c.name, err)
+		}
+
+		approxEq := func(a, b float64) bool { return scalar.EqualWithinAbsOrRel(a, b, 1e-8, 1e-10) }
+		reaction := func(index Index) float64 {
+			value, err := result.Reaction(index)
+			if err != nil {
+				t.Fatalf("%v: expected reaction at %v, got %v", c.name, index, err)
+			}
+			return value.Value
+		}
+
+		// Nothing but the element is attached to the supports, so its end forces are the reactions.
+		start := r3.Vec{
+			X: reaction(Index{NodalID: "A", Dof: Ux}),
+			Z: reaction(Index{NodalID: "A", Dof: Uz}),
+		}
+		end := r3.Vec{Z: reaction(Index{NodalID: "B", Dof: Uz})}
+
+		if !vecEqualWithinAbs(forces.Start.Force, start, 1e-8) ||
+			!vecEqualWithinAbs(forces.End.Force, end, 1e-8) {
+			t.Errorf("%v: expected end forces %v and %v, got %+v", c.name, start, end, forces)
+		}
+
+		moment := reaction(Index{NodalID: "A", Dof: Phiy})
+
+		if !approxEq(forces.Start.Moment.Y, moment) {
+			t.Errorf("%v: expected moment %v at A, got %v", c.name, moment, forces.Start.Moment)
+		}
+
+		// The local z-axis points downwards, and the load is shared 5:3 between the flexible ends.
+		ql := 1e3 * c.l
+
+		if !approxEq(forces.Start.V, -5*ql/8) || !approxEq(forces.End.V, -3*ql/8) {
+			t.Errorf("%v: expected shear end forces %v and %v, got %+v", c.name, -5*ql/8, -3*ql/8, forces)
+		}
+
+		if forces.Start.N != 0 || forces.End.N != 0 || !approxEq(forces.End.M, 0) {
+			t.Errorf("%v: expected no normal force and a free end moment, got %+v", c.name, forces)
+		}
+
+		// Fixed-end moment ql²/8 of the flexible part, plus the lever of the rigid zone.
+		expected := ql*c.l/8 + 5*ql/8*c.offsets[0].X
+
+		if !approxEq(math.Abs(forces.Start.M), expected) {
+			t.Errorf("%v: expected fixed-end moment %v, got %v", c.name, expected, forces.Start.M)
+		}
+	}
+}
+
+func TestEndForcesAtHinge(t *testing.T) {
+	nodes := []Node{{ID: "A"}, {ID: "C", X: 2}, {ID: "B", X: 4}}
+	hinge := map[Index]struct{}{{NodalID: "C", Dof: Phiy}: {}}
+	ac, errAC := NewFrame2d("AC", &nodes[0], &nodes[1], &exampleMat, map[Index]struct{}{})
+	cb, errCB := NewFrame2d("CB", &nodes[1], &nodes[2], &exampleMat, hinge)
+
+	if errAC != nil || errCB != nil {
+		t.Fatalf("Expected successful frame instantiation, got %v, %v", errAC, errCB)
+	}
+
+	cb.AddLoad(NewElementConstantLoad(Uz, 1e3))
+
+	p := Problem{
+		Nodes:    nodes,
+		Elements: []Element{ac, cb},
+		Dirichlet: []NodalValue{
+			{Index: Index{NodalID: "A", Dof: Ux}, Value: 0},
+			{Index: Index{NodalID: "A", Dof: Uz}, Value: 0},
+			{Index: Index{NodalID: "A", Dof: Phiy}, Value: 0},
+			{Index: Index{NodalID: "B", Dof: Uz}, Value: 0},
+		},
+	}
+	result := solveTestProblem(t, &p)
+	left, errLeft := result.EndForces("AC", 1e-10)
+	right, errRight := result.EndForces("CB", 1e-10)
+
+	if errLeft != nil || errRight != nil {
+		t.Fatalf("Expected end forces, got %v, %v", errLeft, errRight)
+	}
+
+	if math.Abs(left.End.M) > 1e-8 || math.Abs(right.Start.M) > 1e-8 {
+		t.Errorf("Expected released moments at the hinge, got %v and %v", left.End.M, right.Start.M)
+	}
+
+	// Node C is unloaded, so the end forces of both elements cancel out, and CB rests on its
+	// supports with half of its load each.
+	if sum := r3.Add(left.End.Force, right.Start.Force); r3.Norm(sum) > 1e-8 {
+		t.Errorf("Expected balanced end forces at C, got %v", sum)
+	}
+
+	if !scalar.EqualWithinAbsOrRel(right.Start.V, -1e3, 1e-8, 1e-10) {
+		t.Errorf("Expected shear end force -1e3 at the hinge, got %v", right.Start.V)
+	}
+
+	if _, err := result.EndForces("XY", 1e-10); err == nil {
+		t.Errorf("Expected error for unknown element, got nil")
+	}
+}
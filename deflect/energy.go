@@ -0,0 +1,195 @@
+package deflect
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// EnergyBalance holds the elastic strain energy of a solved problem and the work done by external
+// forces on its deformation. By Clapeyron's theorem, the strain energy equals the external work for
+// linear-elastic problems, so a mismatch hints at a wrong solution. Imposed strains, e.g. from a
+// temperature change, are no external forces and break the balance.
+type EnergyBalance struct {
+	// StrainEnergy is the sum of the strain energies of all elements.
+	StrainEnergy float64
+	// NodalWork is ½·F·u of all nodal loads, ElementWork the analogous work of all element loads.
+	NodalWork, ElementWork float64
+	// SupportWork is ½·R·u of the support reactions, which is non-zero for settlements and for
+	// constants of linear constraints only.
+	SupportWork float64
+}
+
+// ExternalWork returns the work of all nodal loads, element loads, and support reactions.
+func (eb EnergyBalance) ExternalWork() float64 {
+	return eb.NodalWork + eb.ElementWork + eb.SupportWork
+}
+
+// Imbalance returns the difference between external work and strain energy relative to the larger
+// of both magnitudes, but at least relative to imbalanceFloor. The floor keeps round-off from
+// counting as an imbalance when both vanish, e.g. for rigid body motions due to settlements.
+func (eb EnergyBalance) Imbalance() float64 {
+	work := eb.ExternalWork()
+	scale := max(math.Abs(work), math.Abs(eb.StrainEnergy), imbalanceFloor)
+
+	return math.Abs(work-eb.StrainEnergy) / scale
+}
+
+// imbalanceFloor is the smallest energy that [EnergyBalance.Imbalance] relates differences to. It
+// is small compared to the energies of structural problems in any common units.
+const imbalanceFloor = 1e-9
+
+func (sr *solverResult) StrainEnergy(elmtID string, zeroTol float64) (float64, error) {
+	if elmt, err := scanForElement(elmtID, sr.elements); err == nil {
+		if s, ok := elmt.(*Superelement); ok {
+			energy, _, err := sr.condensedEnergy(s, zeroTol)
+			return energy, err
+		}
+	}
+
+	material, n, my, err := sr.axialAndBending(elmtID, zeroTol)
+	if err != nil {
+		return 0, err
+	}
+
+	EA := material.YoungsModulus * material.Area()
+	EI := material.YoungsModulus * material.Iyy()
+
+	// Both integrals vanish for absent interpolations, e.g. the bending moment of a truss.
+	return 0.5 * (n.Multiply(n).DefiniteIntegral()/EA + my.Multiply(my).DefiniteIntegral()/EI), nil
+}
+
+func (sr *solverResult) Energy(zeroTol float64) (EnergyBalance, error) {
+	var result EnergyBalance
+	var err error
+
+	for _, e := range sr.elements {
+		if s, ok := e.(*Superelement); ok {
+			energy, work, errCondensed := sr.condensedEnergy(s, zeroTol)
+			result.StrainEnergy += energy
+			result.ElementWork += work
+			err = errors.Join(err, errCondensed)
+			continue
+		}
+
+		energy, errEnergy := sr.StrainEnergy(e.ID(), zeroTol)
+		work, errWork := sr.elementLoadWork(e, zeroTol)
+
+		result.StrainEnergy += energy
+		result.ElementWork += work
+		err = errors.Join(err, errEnergy, errWork)
+	}
+
+	for _, load := range sr.neumann {
+		u, errPrimary := sr.Primary(load.Index)
+		result.NodalWork += 0.5 * load.Value * u.Value
+		err = errors.Join(err, errPrimary)
+	}
+
+	result.SupportWork = sr.supportWork
+
+	return result, err
+}
+
+// supportWork returns ½·R·u of the reactions r, where the first constrained indices are the ones
+// with Dirichlet BCs. Only these carry reactions in the coordinates of the solved system, i.e.,
+// before any [Transformer.Post]. Afterwards, a reaction of an inclined support or a constraint may
+// be spread over several indices, some of which are free. The work is the same in all coordinates.
+// Reactions that vanish up to round-off relative to the magnitudes in scale, see residualAndScale,
+// don't contribute, e.g. for a rigid body motion due to settlements.
+func supportWork(r, d, scale *mat.VecDense, constrained int) float64 {
+	var result float64
+
+	// Only settlements and constants of constraints displace constrained indices.
+	for i := range constrained {
+		if reaction := r.AtVec(i); math.Abs(reaction) > 1e-10*scale.AtVec(i) {
+			result += 0.5 * reaction * d.AtVec(i)
+		}
+	}
+
+	return result
+}
+
+// condensedEnergy returns the strain energy and the work of element loads of the elements that s
+// condenses. The work of the interface forces of the recovered substructure is left out, since
+// these are internal forces of the whole problem.
+func (sr *solverResult) condensedEnergy(
+	s *Superelement,
+	zeroTol float64,
+) (energy, work float64, err error) {
+	recovered, errRecover := s.Recover(sr)
+	if errRecover != nil {
+		return 0, 0, errRecover
+	}
+
+	balance, err := recovered.Energy(zeroTol)
+
+	return balance.StrainEnergy, balance.ElementWork, err
+}
+
+// elementLoadWork returns ½·∫ q·u dx of all distributed loads of the element plus ½·F·u of its
+// concentrated loads, where q and F are in the local co-system, and u is the matching local
+// displacement or rotation.
+func (sr *solverResult) elementLoadWork(elmt Element, zeroTol float64) (float64, error) {
+	loaded, ok := elmt.(interface {
+		elementLoads() []NeumannElementBC
+		loadedLength() float64
+	})
+
+	if !ok {
+		return 0, fmt.Errorf("can't determine the work of loads of element %v", elmt.ID())
+	}
+
+	displacements := map[Dof]Fct{Ux: FctUx, Uz: FctUz, Phiy: FctPhiy}
+	l := loaded.loadedLength()
+	var result float64
+
+	for _, bc := range loaded.elementLoads() {
+		var kind Dof
+		var q PolyPiece
+		var concentrated *neumannConcentrated
+		imposed := false
+
+		loadDispatch(bc,
+			func(load *neumannConcentrated) { kind, concentrated = load.kind, load },
+			func(load *neumannConstant) {
+				kind, q = load.kind, PolyPiece{X0: 0, XE: l, Coeff: []float64{load.value}}
+			},
+			func(load *neumannLinear) { kind, q = load.kind, load.piece(l) },
+			func(load *neumannPolynomial) { kind, q = load.kind, load.piece(l) },
+			func(*neumannInitialStrain) { imposed = true })
+
+		if imposed {
+			// An imposed strain isn't an external force and doesn't do any work.
+			continue
+		}
+
+		fct, ok := displacements[kind]
+		if !ok {
+			return 0, fmt.Errorf("can't determine the work of %v loads of element %v", kind, elmt.ID())
+		}
+
+		u, err := sr.Interpolate(elmt.ID(), fct, zeroTol)
+		if err != nil {
+			return 0, err
+		} else if u.Piecewise == nil {
+			return 0, fmt.Errorf("element %v has no %v interpolation for its loads", elmt.ID(), kind)
+		}
+
+		if concentrated == nil {
+			result += 0.5 * PolySequence{q}.Multiply(u.Piecewise).DefiniteIntegral()
+			continue
+		}
+
+		value, err := evalRightOf(u.Piecewise, concentrated.position)
+		if err != nil {
+			return 0, fmt.Errorf("element %v: %w", elmt.ID(), err)
+		}
+
+		result += 0.5 * concentrated.value * value
+	}
+
+	return result, nil
+}
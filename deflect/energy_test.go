@@ -0,0 +1,241 @@
+package deflect
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/floats/scalar"
+)
+
+func TestStrainEnergyCantilever(t *testing.T) {
+	p := cantileverTestProblem(t)
+	result := solveTestProblem(t, &p)
+
+	// Tip loads Fz = 1e3 and Fx = -2e3 at l = 2: U = Fz²·l³/(6·EI) + Fx²·l/(2·EA).
+	EI := exampleMat.YoungsModulus * exampleMat.Iyy()
+	EA := exampleMat.YoungsModulus * exampleMat.Area()
+	expected := 1e6*8/(6*EI) + 4e6*2/(2*EA)
+
+	energy, err := result.StrainEnergy("AB", 1e-10)
+
+	if err != nil {
+		t.Fatalf("Expected successful strain energy computation, got %v", err)
+	}
+
+	if !scalar.EqualWithinAbsOrRel(energy, expected, 1e-10, 1e-10) {
+		t.Errorf("Expected strain energy %v, got %v", expected, energy)
+	}
+
+	// The prescribed support displacement is a rigid body motion. It changes the work of the tip
+	// loads, which must be balanced by the work of the support reactions.
+	balance, err := result.Energy(1e-10)
+
+	if err != nil {
+		t.Fatalf("Expected successful energy balance, got %v", err)
+	}
+
+	if !scalar.EqualWithinAbsOrRel(balance.StrainEnergy, expected, 1e-10, 1e-10) {
+		t.Errorf("Expected total strain energy %v, got %v", expected, balance.StrainEnergy)
+	}
+
+	if balance.SupportWork == 0 || balance.Imbalance() > 1e-10 {
+		t.Errorf("Expected balanced energy with support work, got %+v", balance)
+	}
+
+	if _, err := result.StrainEnergy("XY", 1e-10); err == nil {
+		t.Errorf("Expected error for unknown element, got nil")
+	}
+}
+
+func TestEnergyOfRigidBodySettlement(t *testing.T) {
+	p := cantileverTestProblem(t)
+	p.Neumann = nil
+	balance, err := solveTestProblem(t, &p).Energy(1e-10)
+
+	if err != nil {
+		t.Fatalf("Expected successful energy balance, got %v", err)
+	}
+
+	// Without loads, the settlement doesn't deform the cantilever, and only round-off remains:
+	if balance.SupportWork != 0 || balance.Imbalance() > 1e-10 {
+		t.Errorf("Expected balanced energy without support work, got %+v", balance)
+	}
+
+	roundOff := EnergyBalance{StrainEnergy: 1e-20, NodalWork: 3e-20}
+
+	if imbalance := roundOff.Imbalance(); imbalance > 1e-10 {
+		t.Errorf("Expected round-off energies to balance, got imbalance %v", imbalance)
+	}
+}
+
+func TestEnergyBalanceElementLoads(t *testing.T) {
+	concentrated := func(kind Dof, pos, value float64) NeumannElementBC {
+		load, err := NewElementConcentratedLoad(kind, pos, value)
+		if err != nil {
+			t.Fatalf("Expected valid concentrated load, got %v", err)
+		}
+		return load
+	}
+	global := func(load NeumannElementBC) NeumannElementBC {
+		result, err := NewElementGlobalLoad(load)
+		if err != nil {
+			t.Fatalf("Expected valid global load, got %v", err)
+		}
+		return result
+	}
+	polynomial, errPoly := NewElementPolynomialLoad(Uz, []float64{1e3, -2e2, 3e1})
+	partial, errPartial := NewElementPartialLinearLoad(Uz, 0.5, 2.5, 1e3, 3e3)
+
+	if errPoly != nil || errPartial != nil {
+		t.Fatalf("Expected valid element loads, got %v, %v", errPoly, errPartial)
+	}
+
+	cases := []struct {
+		name  string
+		z1    float64
+		loads []NeumannElementBC
+	}{
+		{name: "constant", loads: []NeumannElementBC{NewElementConstantLoad(Uz, 1e3)}},
+		{name: "axial", loads: []NeumannElementBC{NewElementConstantLoad(Ux, -2e3)}},
+		{name: "concentrated force", loads: []NeumannElementBC{concentrated(Uz, 1.2, 5e3)}},
+		{name: "concentrated moment", loads: []NeumannElementBC{concentrated(Phiy, 0.7, 4e3)}},
+		{name: "partial linear", loads: []NeumannElementBC{partial}},
+		{name: "polynomial", loads: []NeumannElementBC{polynomial}},
+		{name: "inclined global", z1: 1.5, loads: []NeumannElementBC{
+			global(NewElementConstantLoad(Uz, 2e3)),
+			concentrated(Ux, 1, 1e3),
+		}},
+	}
+
+	for _, c := range cases {
+		nodes := []Node{{ID: "A"}, {ID: "B", X: 3, Z: c.z1}}
+		elmt, err := NewFrame2d("AB", &nodes[0], &nodes[1], &exampleMat, map[Index]struct{}{})
+
+		if err != nil {
+			t.Fatalf("%v: expected successful frame instantiation, got %v", c.name, err)
+		}
+
+		for _, load := range c.loads {
+			if !elmt.AddLoad(load) {
+				t.Fatalf("%v: expected load %v to be accepted", c.name, load)
+			}
+		}
+
+		// A propped cantilever, i.e., statically indeterminate.
+		p := Problem{
+			Nodes:    nodes,
+			Elements: []Element{elmt},
+			Dirichlet: []NodalValue{
+				{Index: Index{NodalID: "A", Dof: Ux}, Value: 0},
+				{Index: Index{NodalID: "A", Dof: Uz}, Value: 0},
+				{Index: Index{NodalID: "A", Dof: Phiy}, Value: 0},
+				{Index: Index{NodalID: "B", Dof: Uz}, Value: 0},
+			},
+		}
+		balance, err := solveTestProblem(t, &p).Energy(1e-10)
+
+		if err != nil {
+			t.Fatalf("%v: expected successful energy balance, got %v", c.name, err)
+		}
+
+		if balance.StrainEnergy <= 0 || balance.NodalWork != 0 || balance.SupportWork != 0 {
+			t.Errorf("%v: expected strain energy by element loads only, got %+v", c.name, balance)
+		}
+
+		if imbalance := balance.Imbalance(); imbalance > 1e-8 {
+			t.Errorf("%v: expected balanced energy, got %+v (%v)", c.name, balance, imbalance)
+		}
+	}
+}
+
+func TestEnergyImbalanceByInitialStrain(t *testing.T) {
+	nodes := []Node{{ID: "A"}, {ID: "B", X: 2}, {ID: "C", X: 4}}
+	hinges := map[Index]struct{}{}
+	left, errLeft := NewTruss2d("AB", &nodes[0], &nodes[1], &exampleMat, hinges)
+	right, errRight := NewTruss2d("BC", &nodes[1], &nodes[2], &exampleMat, hinges)
+
+	if errLeft != nil || errRight != nil {
+		t.Fatalf("Expected successful truss instantiation, got %v, %v", errLeft, errRight)
+	}
+
+	left.AddLoad(NewElementInitialStrain(1e-3))
+	right.AddLoad(NewElementInitialStrain(1e-3))
+
+	p := Problem{
+		Nodes:    nodes,
+		Elements: []Element{left, right},
+		Dirichlet: []NodalValue{
+			{Index: Index{NodalID: "A", Dof: Ux}, Value: 0},
+			{Index: Index{NodalID: "A", Dof: Uz}, Value: 0},
+			{Index: Index{NodalID: "B", Dof: Uz}, Value: 0},
+			{Index: Index{NodalID: "C", Dof: Ux}, Value: 0},
+			{Index: Index{NodalID: "C", Dof: Uz}, Value: 0},
+		},
+	}
+	balance, err := solveTestProblem(t, &p).Energy(1e-10)
+
+	if err != nil {
+		t.Fatalf("Expected successful energy balance, got %v", err)
+	}
+
+	// Restrained at both ends, there is strain energy, but no load does any work.
+	EA := exampleMat.YoungsModulus * exampleMat.Area()
+	expected := 0.5 * EA * 1e-3 * 1e-3 * 4
+
+	if !scalar.EqualWithinAbsOrRel(balance.StrainEnergy, expected, 1e-10, 1e-10) {
+		t.Errorf("Expected strain energy %v, got %v", expected, balance.StrainEnergy)
+	}
+
+	if balance.ExternalWork() != 0 || balance.Imbalance() != 1 {
+		t.Errorf("Expected imbalance without external work, got %+v", balance)
+	}
+}
+
+func TestEnergyWithSuperelement(t *testing.T) {
+	nodes := []Node{{ID: "N0"}, {ID: "N1", X: 1, Z: 0.2}, {ID: "N2", X: 2}}
+	full := Problem{
+		Nodes:     nodes,
+		Elements:  continuousBeamFrames(t, nodes),
+		Dirichlet: superelementTestSupports("N2"),
+	}
+	expected, err := solveTestProblem(t, &full).Energy(1e-10)
+	if err != nil {
+		t.Fatalf("Expected successful energy balance, got %v", err)
+	}
+
+	module := []Node{{ID: "T0"}, {ID: "T1", X: 1, Z: 0.2}, {ID: "T2", X: 2}}
+	template, err := NewSuperelement("S0", continuousBeamFrames(t, module), []string{"T0", "T2"})
+	if err != nil {
+		t.Fatalf("Expected successful condensation, got %v", err)
+	}
+
+	instance, err := template.Instance("S1", map[string]string{"T0": "N0", "T2": "N2"})
+	if err != nil {
+		t.Fatalf("Expected successful instantiation, got %v", err)
+	}
+
+	condensed := Problem{
+		Nodes:     []Node{nodes[0], nodes[2]},
+		Elements:  []Element{instance},
+		Dirichlet: superelementTestSupports("N2"),
+	}
+	result := solveTestProblem(t, &condensed)
+	actual, err := result.Energy(1e-10)
+
+	if err != nil {
+		t.Fatalf("Expected successful energy balance with superelement, got %v", err)
+	}
+
+	approxEq := func(a, b float64) bool { return scalar.EqualWithinAbsOrRel(a, b, 1e-10, 1e-10) }
+
+	if !approxEq(actual.StrainEnergy, expected.StrainEnergy) ||
+		!approxEq(actual.ElementWork, expected.ElementWork) || actual.Imbalance() > 1e-10 {
+		t.Errorf("Expected energy balance %+v, got %+v", expected, actual)
+	}
+
+	energy, err := result.StrainEnergy("S1", 1e-10)
+
+	if err != nil || !approxEq(energy, expected.StrainEnergy) {
+		t.Errorf("Expected strain energy %v of superelement, got %v (%v)", expected.StrainEnergy,
+			energy, err)
+	}
+}
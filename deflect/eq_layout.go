@@ -98,6 +98,7 @@ func (el *EqLayout) flushFailure() error {
 // NewEqLayout creates a new index layout for the given boundary value problem.
 func NewEqLayout(p *Problem) (EqLayout, error) {
 	indices, err := createIndexMap(p.Elements, p.Dirichlet)
+	err = errors.Join(err, checkSlaves(p.EqTransforms, p.Dirichlet))
 
 	return newEqLayoutDirect(indices), err
 }
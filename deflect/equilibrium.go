@@ -0,0 +1,229 @@
+package deflect
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"gonum.org/v1/gonum/spatial/r3"
+)
+
+// EquilibriumResidual is the sum of all nodal loads, element loads, and reactions of a solved
+// problem, where moments are taken about the origin. Both sums vanish for a correct solution.
+type EquilibriumResidual struct {
+	Force, Moment r3.Vec
+	// ForceScale and MomentScale are the sums of the magnitudes of all terms of the reactions k·d -
+	// r, i.e., of loads and internal forces, which the residuals can be compared against. A force f
+	// at position x contributes |x|·|f| to the moment scale.
+	ForceScale, MomentScale float64
+}
+
+// Relative returns the magnitudes of the residual force and moment divided by their scales, or
+// zero where nothing contributes.
+func (er EquilibriumResidual) Relative() (force, moment float64) {
+	relative := func(v r3.Vec, scale float64) float64 {
+		if scale == 0 {
+			return 0
+		}
+
+		return r3.Norm(v) / scale
+	}
+
+	return relative(er.Force, er.ForceScale), relative(er.Moment, er.MomentScale)
+}
+
+// add accumulates the force f acting at position x and the moment m.
+func (er *EquilibriumResidual) add(x, f, m r3.Vec) {
+	er.Force = r3.Add(er.Force, f)
+	er.Moment = r3.Add(er.Moment, r3.Add(r3.Cross(x, f), m))
+}
+
+// isRotation returns true if the given degree of freedom is a rotation rather than a translation.
+func isRotation(dof Dof) bool {
+	return dof == Phiy || dof == Phiz || dof == Phix
+}
+
+func (sr *solverResult) Equilibrium() (EquilibriumResidual, error) {
+	var result EquilibriumResidual
+	var err error
+
+	positions := make(map[string]r3.Vec, len(sr.nodes))
+
+	for _, n := range sr.nodes {
+		positions[n.ID] = r3.Vec{X: n.X, Y: n.Y, Z: n.Z}
+	}
+
+	position := func(nodalID string) r3.Vec {
+		x, ok := positions[nodalID]
+
+		if !ok {
+			err = errors.Join(err, fmt.Errorf("no node with ID '%v' found", nodalID))
+		}
+
+		return x
+	}
+
+	addNodal := func(nv NodalValue) {
+		x, vector := position(nv.NodalID), r3.Scale(nv.Value, dofAxis(nv.Dof))
+
+		if isRotation(nv.Dof) {
+			result.add(x, r3.Vec{}, vector)
+		} else {
+			result.add(x, vector, r3.Vec{})
+		}
+	}
+
+	for _, load := range sr.neumann {
+		addNodal(load)
+	}
+
+	// Reactions, plus the residual of free degrees of freedom, which belongs to the sum, too.
+	for i := range sr.total {
+		index := sr.indices.unmap(i)
+		addNodal(NodalValue{Index: index, Value: sr.residual.AtVec(i)})
+
+		if magnitude := sr.scale.AtVec(i); isRotation(index.Dof) {
+			result.MomentScale += magnitude
+		} else {
+			result.ForceScale += magnitude
+			result.MomentScale += r3.Norm(position(index.NodalID)) * magnitude
+		}
+	}
+
+	err = errors.Join(err, sr.indices.flushFailure())
+
+	for _, e := range sr.elements {
+		err = errors.Join(err, loadResultant(e, &result))
+	}
+
+	return result, err
+}
+
+func (sr *solverResult) Warnings() []string {
+	return sr.warnings
+}
+
+// checkEquilibrium returns a warning if the relative equilibrium residual of the result exceeds
+// the given tolerance, or if it can't be determined.
+func checkEquilibrium(sr *solverResult, tol float64) []string {
+	if slices.ContainsFunc(sr.elements, hasInstance) {
+		// The load resultant of instances is unknown, which isn't worth a warning, see loadResultant.
+		return nil
+	}
+
+	residual, err := sr.Equilibrium()
+
+	if err != nil {
+		return []string{fmt.Sprintf("equilibrium not checked: %v", err)}
+	}
+
+	force, moment := residual.Relative()
+
+	// Also warns about NaN residuals, which compare false to anything.
+	if !(force <= tol && moment <= tol) {
+		return []string{fmt.Sprintf(
+			"equilibrium violated, residual force %v and moment %v exceed relative tolerance %v",
+			residual.Force, residual.Moment, tol)}
+	}
+
+	return nil
+}
+
+// hasInstance returns true if elmt is a superelement instance or condenses one.
+func hasInstance(elmt Element) bool {
+	s, ok := elmt.(*Superelement)
+
+	return ok && (s.rename != nil || slices.ContainsFunc(s.condensed.elements, hasInstance))
+}
+
+// dofAxis returns the global unit vector of the given degree of freedom.
+func dofAxis(dof Dof) r3.Vec {
+	switch dof {
+	case Ux, Phix:
+		return r3.Vec{X: 1}
+	case Uy, Phiy:
+		return r3.Vec{Y: 1}
+	default:
+		return r3.Vec{Z: 1}
+	}
+}
+
+// loadResultant adds the element loads of elmt to the given residual. Imposed strains are no
+// external forces and don't contribute.
+func loadResultant(elmt Element, residual *EquilibriumResidual) error {
+	if s, ok := elmt.(*Superelement); ok {
+		if s.rename != nil {
+			// The translation of an instance is unknown, and so is the moment of its loads.
+			return fmt.Errorf("can't determine the load resultant of instance %v", s.ID())
+		}
+
+		var err error
+
+		for _, e := range s.condensed.elements {
+			err = errors.Join(err, loadResultant(e, residual))
+		}
+
+		return err
+	}
+
+	loaded, ok := elmt.(interface {
+		elementLoads() []NeumannElementBC
+		loadedLength() float64
+		axes() (origin, ex, ez r3.Vec)
+	})
+
+	if !ok {
+		return fmt.Errorf("can't determine the load resultant of element %v", elmt.ID())
+	}
+
+	l := loaded.loadedLength()
+	origin, ex, ez := loaded.axes()
+	directions := map[Dof]r3.Vec{Ux: ex, Uz: ez}
+
+	for _, bc := range loaded.elementLoads() {
+		var kind Dof
+		var q PolyPiece
+		var concentrated *neumannConcentrated
+		imposed := false
+
+		loadDispatch(bc,
+			func(load *neumannConcentrated) { kind, concentrated = load.kind, load },
+			func(load *neumannConstant) {
+				kind, q = load.kind, PolyPiece{X0: 0, XE: l, Coeff: []float64{load.value}}
+			},
+			func(load *neumannLinear) { kind, q = load.kind, load.piece(l) },
+			func(load *neumannPolynomial) { kind, q = load.kind, load.piece(l) },
+			func(*neumannInitialStrain) { imposed = true })
+
+		if imposed {
+			continue
+		} else if concentrated != nil && kind == Phiy {
+			// Element moments act about the local y-axis, which is opposite to the global one in 2d.
+			residual.add(origin, r3.Vec{}, r3.Scale(concentrated.value, r3.Cross(ez, ex)))
+			continue
+		}
+
+		direction, ok := directions[kind]
+		if !ok {
+			return fmt.Errorf("can't determine the resultant of %v loads of element %v", kind, elmt.ID())
+		}
+
+		// The resultant force ∫ q dx acts at the first node, together with the couple of the first
+		// moment ∫ q·x dx about it.
+		var magnitude, firstMoment float64
+
+		if concentrated != nil {
+			magnitude = concentrated.value
+			firstMoment = concentrated.value * concentrated.position
+		} else {
+			ps := PolySequence{q}
+			lever := PolySequence{{X0: q.X0, XE: q.XE, Coeff: []float64{0, 1}}}
+			magnitude, firstMoment = ps.DefiniteIntegral(), ps.Multiply(lever).DefiniteIntegral()
+		}
+
+		couple := r3.Cross(ex, r3.Scale(firstMoment, direction))
+		residual.add(origin, r3.Scale(magnitude, direction), couple)
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package deflect
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"gonum.org/v1/gonum/floats/scalar"
+	"gonum.org/v1/gonum/spatial/r3"
+)
+
+func TestEquilibriumOfLoadedBeam(t *testing.T) {
+	p := simplySupportedTestProblem(t)
+	moment, err := NewElementConcentratedLoad(Phiy, 0.5, 2e3)
+
+	if err != nil {
+		t.Fatalf("Expected valid concentrated load, got %v", err)
+	}
+
+	p.Elements[0].AddLoad(NewElementConstantLoad(Uz, 1e3))
+	p.Elements[1].AddLoad(moment)
+	p.Neumann = append(p.Neumann, NodalValue{Index: Index{NodalID: "C", Dof: Phiy}, Value: -3e3})
+
+	result := solveTestProblem(t, &p)
+	residual, err := result.Equilibrium()
+
+	if err != nil {
+		t.Fatalf("Expected successful equilibrium check, got %v", err)
+	}
+
+	if force, moment := residual.Relative(); force > 1e-10 || moment > 1e-10 {
+		t.Errorf("Expected vanishing residual, got %+v", residual)
+	}
+
+	if warnings := result.Warnings(); len(warnings) > 0 {
+		t.Errorf("Expected no warnings, got %v", warnings)
+	}
+
+	// An element load that wasn't part of the solution must show up as the residual.
+	p.Elements[0].AddLoad(NewElementConstantLoad(Uz, 1e3))
+
+	if residual, err = result.Equilibrium(); err != nil {
+		t.Fatalf("Expected successful equilibrium check, got %v", err)
+	}
+
+	// The local z-axis of the elements points downwards, and the load acts at x = 1.
+	expectForce, expectMoment := r3.Vec{Z: -2e3}, r3.Vec{Y: 2e3}
+	approxEq := func(a, b r3.Vec) bool {
+		return scalar.EqualWithinAbs(r3.Norm(r3.Sub(a, b)), 0, 1e-8)
+	}
+
+	if !approxEq(residual.Force, expectForce) || !approxEq(residual.Moment, expectMoment) {
+		t.Errorf("Expected residual %v and %v, got %+v", expectForce, expectMoment, residual)
+	}
+
+	if warnings := checkEquilibrium(result.(*solverResult), 1e-6); len(warnings) != 1 {
+		t.Errorf("Expected a warning for the violated equilibrium, got %v", warnings)
+	}
+
+	sr := result.(*solverResult)
+	nan := NodalValue{Index: Index{NodalID: "C", Dof: Uz}, Value: math.NaN()}
+	sr.neumann = append(sr.neumann, nan)
+
+	if warnings := checkEquilibrium(sr, 1e-6); len(warnings) != 1 {
+		t.Errorf("Expected a warning for a NaN residual, got %v", warnings)
+	}
+}
+
+// opaqueElement hides everything but the Element interface of the wrapped element.
+type opaqueElement struct {
+	Element
+}
+
+func TestEquilibriumWarnings(t *testing.T) {
+	p := cantileverTestProblem(t)
+	p.Elements[0] = opaqueElement{p.Elements[0]}
+
+	indices, err := NewEqLayout(&p)
+	if err != nil {
+		t.Fatalf("Expected valid equation layout, got %v", err)
+	}
+
+	for _, tol := range [...]float64{0, 1e-6} {
+		solver := NewLinearProblemSolver(WithEquilibriumTolerance(tol))
+		result, err := solver.Solve(&p, indices, NewCholeskySolver())
+
+		if err != nil {
+			t.Fatalf("Expected successful solution, got %v", err)
+		}
+
+		warnings := result.Warnings()
+
+		if tol == 0 && len(warnings) != 0 {
+			t.Errorf("Expected no warnings with disabled check, got %v", warnings)
+		} else if tol > 0 && (len(warnings) != 1 || !strings.Contains(warnings[0], "not checked")) {
+			t.Errorf("Expected a warning about the unknown load resultant, got %v", warnings)
+		}
+	}
+}
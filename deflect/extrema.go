@@ -0,0 +1,86 @@
+package deflect
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+)
+
+// Extremum is the value of an interpolated quantity at position x of an element.
+type Extremum struct {
+	Element  string
+	Quantity Fct
+	X, Value float64
+}
+
+// Extrema returns the smallest and largest value of the interpolation and their positions. The
+// candidates are the boundaries of all pieces and the roots of their derivatives, so the result is
+// exact up to round-off and doesn't depend on any sampling. Where a quantity jumps, both one-sided
+// values are considered. If the extreme value is attained at more than one position, e.g. for a
+// constant quantity, the leftmost position is returned.
+func (ip *Interpolation) Extrema() (lowest, highest Extremum, err error) {
+	if len(ip.Piecewise) == 0 {
+		return lowest, highest, fmt.Errorf("interpolation of %v/%v is empty", ip.Element, ip.Quantity)
+	}
+
+	lowest = Extremum{Element: ip.Element, Quantity: ip.Quantity, Value: math.Inf(1)}
+	highest = Extremum{Element: ip.Element, Quantity: ip.Quantity, Value: math.Inf(-1)}
+
+	for _, p := range ip.Piecewise {
+		derivative := p.derive()
+		candidates := append([]float64{p.X0}, derivative.Roots()...)
+		candidates = append(candidates, p.XE)
+
+		for _, x := range candidates {
+			value, errEval := p.Eval(x)
+
+			if errEval != nil {
+				log.Printf("Bug: evaluating polynomial within its domain must not fail: %v", errEval)
+			}
+
+			if value < lowest.Value {
+				lowest.X, lowest.Value = x, value
+			}
+
+			if value > highest.Value {
+				highest.X, highest.Value = x, value
+			}
+		}
+	}
+
+	return lowest, highest, nil
+}
+
+func (sr *solverResult) MaxAbs(quantity Fct, zeroTol float64) (Extremum, error) {
+	var result Extremum
+	var err error
+	found := false
+
+	for _, e := range sr.elements {
+		interpolation, errSingle := sr.Interpolate(e.ID(), quantity, zeroTol)
+
+		if errSingle != nil {
+			err = errors.Join(err, errSingle)
+			continue
+		} else if interpolation.Piecewise == nil {
+			// The element doesn't have this quantity, e.g. a bending moment of a truss.
+			continue
+		}
+
+		lowest, highest, errSingle := interpolation.Extrema()
+		err = errors.Join(err, errSingle)
+
+		for _, candidate := range [...]Extremum{lowest, highest} {
+			if errSingle == nil && (!found || math.Abs(candidate.Value) > math.Abs(result.Value)) {
+				result, found = candidate, true
+			}
+		}
+	}
+
+	if err == nil && !found {
+		err = fmt.Errorf("no element interpolates %v", quantity)
+	}
+
+	return result, err
+}
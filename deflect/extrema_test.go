@@ -0,0 +1,106 @@
+package deflect
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/floats/scalar"
+)
+
+func TestInterpolationExtrema(t *testing.T) {
+	cases := []struct {
+		name              string
+		ps                PolySequence
+		lowest, highest   float64
+		xLowest, xHighest float64
+	}{
+		{
+			name:   "parabola",
+			ps:     PolySequence{{X0: 0, XE: 4, Coeff: []float64{0, 4, -1}}},
+			lowest: 0, xLowest: 0, highest: 4, xHighest: 2,
+		},
+		{
+			name:   "constant",
+			ps:     PolySequence{{X0: 0, XE: 4, Coeff: []float64{-3}}},
+			lowest: -3, xLowest: 0, highest: -3, xHighest: 0,
+		},
+		{
+			// A shear force with a jump at x = 1.
+			name: "jump",
+			ps: PolySequence{
+				{X0: 0, XE: 1, Coeff: []float64{2, -1}},
+				{X0: 1, XE: 3, Coeff: []float64{-1, -1}},
+			},
+			lowest: -4, xLowest: 3, highest: 2, xHighest: 0,
+		},
+		{
+			name: "cubic",
+			// x³ - 3·x has a local maximum at -1 and a local minimum at 1.
+			ps:     PolySequence{{X0: -1.5, XE: 1.5, Coeff: []float64{0, -3, 0, 1}}},
+			lowest: -2, xLowest: 1, highest: 2, xHighest: -1,
+		},
+	}
+
+	approxEq := func(a, b float64) bool { return scalar.EqualWithinAbs(a, b, 1e-10) }
+
+	for _, c := range cases {
+		ip := Interpolation{Element: "E", Quantity: FctMy, Piecewise: c.ps}
+		lowest, highest, err := ip.Extrema()
+
+		if err != nil {
+			t.Fatalf("%v: expected successful extrema search, got %v", c.name, err)
+		}
+
+		if !approxEq(lowest.Value, c.lowest) || !approxEq(lowest.X, c.xLowest) {
+			t.Errorf("%v: expected minimum %v at %v, got %v", c.name, c.lowest, c.xLowest, lowest)
+		}
+
+		if !approxEq(highest.Value, c.highest) || !approxEq(highest.X, c.xHighest) {
+			t.Errorf("%v: expected maximum %v at %v, got %v", c.name, c.highest, c.xHighest, highest)
+		}
+	}
+
+	empty := Interpolation{Element: "E", Quantity: FctMy}
+
+	if _, _, err := empty.Extrema(); err == nil {
+		t.Errorf("Expected error for empty interpolation, got nil")
+	}
+}
+
+func TestMaxAbsMoment(t *testing.T) {
+	nodes := []Node{{ID: "A"}, {ID: "B", X: 1}, {ID: "C", X: 4}}
+	hinges := map[Index]struct{}{}
+	left, _ := NewFrame2d("AB", &nodes[0], &nodes[1], &exampleMat, hinges)
+	right, _ := NewFrame2d("BC", &nodes[1], &nodes[2], &exampleMat, hinges)
+	left.AddLoad(NewElementConstantLoad(Uz, 1e3))
+	right.AddLoad(NewElementConstantLoad(Uz, 1e3))
+
+	p := Problem{
+		Nodes:    nodes,
+		Elements: []Element{left, right},
+		Dirichlet: []NodalValue{
+			{Index: Index{NodalID: "A", Dof: Ux}, Value: 0},
+			{Index: Index{NodalID: "A", Dof: Uz}, Value: 0},
+			{Index: Index{NodalID: "C", Dof: Uz}, Value: 0},
+		},
+	}
+	result := solveTestProblem(t, &p)
+
+	// The field moment q·l²/8 at mid-span, which is in the second element.
+	moment, err := result.MaxAbs(FctMy, 1e-8)
+
+	if err != nil {
+		t.Fatalf("Expected successful query, got %v", err)
+	}
+
+	if moment.Element != "BC" || !scalar.EqualWithinAbs(moment.X, 1, 1e-10) {
+		t.Errorf("Expected maximum moment at mid-span, got %v", moment)
+	}
+
+	if !scalar.EqualWithinAbsOrRel(moment.Value, 2e3, 1e-10, 1e-10) {
+		t.Errorf("Expected maximum moment of magnitude 2e3, got %v", moment)
+	}
+
+	if _, err := result.MaxAbs(FctMx, 1e-8); err == nil {
+		t.Errorf("Expected error for a quantity without interpolation, got nil")
+	}
+}
@@ -21,11 +21,13 @@ func _() {
 	_ = x[FctMy-10]
 	_ = x[FctMz-11]
 	_ = x[FctMx-12]
+	_ = x[FctEpsx-13]
+	_ = x[FctKappay-14]
 }
 
-const _Fct_name = "UnknownUxUzUyPhiyPhizPhixNxVzVyMyMzMx"
+const _Fct_name = "UnknownUxUzUyPhiyPhizPhixNxVzVyMyMzMxEpsxKappay"
 
-var _Fct_index = [...]uint8{0, 7, 9, 11, 13, 17, 21, 25, 27, 29, 31, 33, 35, 37}
+var _Fct_index = [...]uint8{0, 7, 9, 11, 13, 17, 21, 25, 27, 29, 31, 33, 35, 37, 41, 47}
 
 func (i Fct) String() string {
 	if i >= Fct(len(_Fct_index)-1) {
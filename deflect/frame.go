@@ -67,9 +67,51 @@ func (f *frame) Indices(set map[Index]struct{}) {
 }
 
 func (f *frame) AddLoad(bc NeumannElementBC) bool {
+	if global, ok := bc.(*neumannGlobal); ok {
+		// Distribute the parts, since neither the truss nor the beam accepts both of them. Either
+		// both parts are applied, or none. The parts are fresh instances, so removing them is safe.
+		axial, transverse := global.local(sineCosine2d(f.n0, f.n1))
+		okAxial := axial == nil || f.truss.AddLoad(axial)
+		okTransverse := transverse == nil || f.beam.AddLoad(transverse)
+
+		if !okAxial || !okTransverse {
+			f.truss.RemoveLoad(axial)
+			f.beam.RemoveLoad(transverse)
+			return false
+		}
+
+		for _, part := range [...]NeumannElementBC{axial, transverse} {
+			if part != nil {
+				f.addGlobalPart(global, part)
+			}
+		}
+
+		return true
+	}
+
 	return f.truss.AddLoad(bc) || f.beam.AddLoad(bc)
 }
 
+func (f *frame) RemoveLoad(bc NeumannElementBC) {
+	for _, load := range append(f.takeGlobalParts(bc), bc) {
+		f.truss.RemoveLoad(load)
+		f.beam.RemoveLoad(load)
+	}
+}
+
+// elementLoads returns the loads of both the truss and the beam part, which hold them separately.
+func (f *frame) elementLoads() []NeumannElementBC {
+	var result []NeumannElementBC
+
+	for _, part := range [...]Element{f.truss, f.beam} {
+		if loaded, ok := part.(interface{ elementLoads() []NeumannElementBC }); ok {
+			result = append(result, loaded.elementLoads()...)
+		}
+	}
+
+	return result
+}
+
 func (f *frame) Interpolate(indices EqLayout, which Fct, d *mat.VecDense) PolySequence {
 	s0 := f.truss.Interpolate(indices, which, d)
 	s1 := f.beam.Interpolate(indices, which, d)
@@ -0,0 +1,315 @@
+package deflect
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/floats/scalar"
+)
+
+// Axle is a concentrated load of a load train. Its position is the reference position of the train
+// plus the offset.
+type Axle struct {
+	Offset, Load float64
+}
+
+// MovingLoad describes a load train that moves along a path of elements.
+type MovingLoad struct {
+	// Path lists the IDs of the elements the loads move along. Each element is traversed from its
+	// first to its second node, consecutive elements must share the node in between, and positions
+	// along the path are measured from the start of the first element.
+	Path []string
+	// Kind is the direction of the loads in element coordinates, usually Uz.
+	Kind Dof
+	// Axles of the load train. When empty, the train is a single unit load.
+	Axles []Axle
+	// Step is the largest distance between two positions of the unit load. Each element of the path
+	// is subdivided evenly, such that its nodes are always part of the positions.
+	Step float64
+}
+
+// InfluenceQuantity extracts a scalar value of interest from a result, see [ReactionInfluence],
+// [PrimaryInfluence], and [InterpolationInfluence].
+type InfluenceQuantity func(result ProblemResult) (float64, error)
+
+// ReactionInfluence selects the reaction of the given index.
+func ReactionInfluence(index Index) InfluenceQuantity {
+	return func(result ProblemResult) (float64, error) {
+		reaction, err := result.Reaction(index)
+		return reaction.Value, err
+	}
+}
+
+// PrimaryInfluence selects the primary nodal value of the given index, e.g. a displacement.
+func PrimaryInfluence(index Index) InfluenceQuantity {
+	return func(result ProblemResult) (float64, error) {
+		primary, err := result.Primary(index)
+		return primary.Value, err
+	}
+}
+
+// InterpolationInfluence selects the interpolated quantity of the given element at position x,
+// e.g. the bending moment in a cross section. Where the quantity jumps, e.g. the shear force below
+// a concentrated load, the value right of x is used, except at the element end.
+func InterpolationInfluence(elmtID string, which Fct, x float64) InfluenceQuantity {
+	return func(result ProblemResult) (float64, error) {
+		interpolation, err := result.Interpolate(elmtID, which, 0)
+
+		if err != nil {
+			return 0, err
+		}
+
+		return evalRightOf(interpolation.Piecewise, x)
+	}
+}
+
+// GoverningPosition pairs a reference position of a load train with the resulting value of a
+// quantity.
+type GoverningPosition struct {
+	Position, Value float64
+}
+
+// InfluenceLines holds the results of [ComputeInfluenceLines]. All slices with one entry per
+// quantity have the order of the quantities passed to [ComputeInfluenceLines].
+type InfluenceLines struct {
+	// Positions of the unit load along the path, in ascending order.
+	Positions []float64
+	// Values holds one influence line per quantity, i.e., the value of the quantity for a unit load
+	// at each of the positions.
+	Values [][]float64
+	// Max and Min hold per quantity the reference position of the load train that results in the
+	// largest and smallest value.
+	Max, Min []GoverningPosition
+	axles    []Axle
+}
+
+// At returns the value of the influence line of the given quantity for a unit load at the given
+// position, interpolated linearly between the computed positions. Outside of the path, the value
+// is zero.
+func (il *InfluenceLines) At(quantity int, position float64) float64 {
+	ps, values := il.Positions, il.Values[quantity]
+	last := len(ps) - 1
+	tol := 1e-10 * max(1, ps[last])
+
+	if position < ps[0]-tol || position > ps[last]+tol {
+		return 0
+	}
+
+	i := sort.SearchFloat64s(ps, position)
+
+	if i == 0 {
+		return values[0]
+	} else if i > last {
+		return values[last]
+	}
+
+	t := (position - ps[i-1]) / (ps[i] - ps[i-1])
+
+	return (1-t)*values[i-1] + t*values[i]
+}
+
+// TrainValue returns the value of the given quantity for the load train at the given reference
+// position by superposition of the influence line. Axles outside of the path don't contribute.
+func (il *InfluenceLines) TrainValue(quantity int, position float64) float64 {
+	var result float64
+
+	for _, axle := range il.axles {
+		result += axle.Load * il.At(quantity, position+axle.Offset)
+	}
+
+	return result
+}
+
+// ComputeInfluenceLines moves a unit load along the path of the given moving load, solves the
+// problem for each position, and records the given quantities. The influence lines are then
+// superimposed for the load train to determine its governing positions, where every reference
+// position with one axle on a computed unit load position is considered. Loads that are already
+// part of the problem don't contribute, since their effect is subtracted. Elements of the path are
+// left with their original loads on return. With a [Factorizer] strategy and a solver created with
+// [WithFactorizationReuse], the coefficient matrix is factorised only once for all positions.
+func ComputeInfluenceLines(
+	p *Problem,
+	indices EqLayout,
+	solver ProblemSolver,
+	strategy EquationSolver,
+	load MovingLoad,
+	quantities []InfluenceQuantity,
+) (*InfluenceLines, error) {
+	if len(load.Path) == 0 {
+		return nil, errors.New("moving load requires a non-empty path")
+	} else if load.Step <= 0 {
+		return nil, fmt.Errorf("moving load requires a positive step, not %v", load.Step)
+	}
+
+	stations, errStations := movingLoadStations(p, &load)
+	if errStations != nil {
+		return nil, errStations
+	}
+
+	base, errBase := influenceValues(p, indices, solver, strategy, quantities)
+	if errBase != nil {
+		return nil, fmt.Errorf("solve problem without moving load: %w", errBase)
+	}
+
+	result := &InfluenceLines{
+		Positions: make([]float64, len(stations)),
+		Values:    make([][]float64, len(quantities)),
+		axles:     load.Axles,
+	}
+
+	for i := range quantities {
+		result.Values[i] = make([]float64, len(stations))
+	}
+
+	for j, station := range stations {
+		unit, errLoad := NewElementConcentratedLoad(load.Kind, station.x, 1)
+
+		if errLoad != nil {
+			return nil, errLoad
+		} else if !station.elmt.AddLoad(unit) {
+			id := station.elmt.ID()
+			return nil, fmt.Errorf("element %v doesn't accept moving loads of kind %v", id, load.Kind)
+		}
+
+		values, err := influenceValues(p, indices, solver, strategy, quantities)
+		station.elmt.RemoveLoad(unit)
+
+		if err != nil {
+			return nil, fmt.Errorf("solve problem with unit load at %v: %w", station.position, err)
+		}
+
+		result.Positions[j] = station.position
+
+		for i := range quantities {
+			result.Values[i][j] = values[i] - base[i]
+		}
+	}
+
+	if len(result.axles) == 0 {
+		result.axles = []Axle{{Offset: 0, Load: 1}}
+	}
+
+	result.governingPositions()
+
+	return result, nil
+}
+
+type movingLoadStation struct {
+	elmt        Element
+	x, position float64
+}
+
+// movingLoadStations returns the positions of the unit load, both in element coordinates and along
+// the path.
+func movingLoadStations(p *Problem, load *MovingLoad) ([]movingLoadStation, error) {
+	var result []movingLoadStation
+	var start float64
+	var previous *Node
+
+	for i, elmtID := range load.Path {
+		elmt, err := scanForElement(elmtID, p.Elements)
+		if err != nil {
+			return nil, fmt.Errorf("moving load path: %w", err)
+		}
+
+		geometry, ok := elmt.(lineGeometry)
+		if !ok {
+			return nil, fmt.Errorf("moving load path: element %v is not one-dimensional", elmtID)
+		}
+
+		n0, n1 := geometry.endNodes()
+
+		if previous != nil && previous.ID != n0.ID {
+			return nil, fmt.Errorf("moving load path: element %v doesn't start at node %v, where %v ends",
+				elmtID, previous.ID, load.Path[i-1])
+		}
+
+		previous = n1
+		l := geometry.loadedLength()
+		n := max(1, int(math.Ceil(l/load.Step-1e-10)))
+
+		for j := range n + 1 {
+			if j == 0 && i > 0 {
+				// Same position as the end of the previous element.
+				continue
+			}
+
+			x := l * float64(j) / float64(n)
+			result = append(result, movingLoadStation{elmt: elmt, x: x, position: start + x})
+		}
+
+		start += l
+	}
+
+	return result, nil
+}
+
+// influenceValues solves the problem and evaluates all quantities.
+func influenceValues(
+	p *Problem,
+	indices EqLayout,
+	solver ProblemSolver,
+	strategy EquationSolver,
+	quantities []InfluenceQuantity,
+) ([]float64, error) {
+	result, err := solver.Solve(p, indices, strategy)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]float64, len(quantities))
+
+	for i, quantity := range quantities {
+		value, errSingle := quantity(result)
+		values[i] = value
+		err = errors.Join(err, errSingle)
+	}
+
+	return values, err
+}
+
+func (il *InfluenceLines) governingPositions() {
+	il.Max = make([]GoverningPosition, len(il.Values))
+	il.Min = make([]GoverningPosition, len(il.Values))
+
+	for i := range il.Values {
+		il.Max[i].Value, il.Min[i].Value = math.Inf(-1), math.Inf(1)
+
+		for _, position := range il.Positions {
+			for _, axle := range il.axles {
+				reference := position - axle.Offset
+				value := il.TrainValue(i, reference)
+
+				if value > il.Max[i].Value {
+					il.Max[i] = GoverningPosition{Position: reference, Value: value}
+				}
+
+				if value < il.Min[i].Value {
+					il.Min[i] = GoverningPosition{Position: reference, Value: value}
+				}
+			}
+		}
+	}
+}
+
+// evalRightOf evaluates the piecewise polynomial at x, using the piece right of x if x is on the
+// boundary between two pieces.
+func evalRightOf(ps PolySequence, x float64) (float64, error) {
+	for i := range ps {
+		last := i == len(ps)-1
+		beforeEnd := x < ps[i].XE && !positionsEqual(x, ps[i].XE)
+
+		if (ps[i].X0 <= x || positionsEqual(x, ps[i].X0)) && (beforeEnd || last) {
+			return ps[i].Eval(x)
+		}
+	}
+
+	return 0, fmt.Errorf("%v outside of the interpolation domain", x)
+}
+
+// positionsEqual compares two positions along an element up to round-off.
+func positionsEqual(a, b float64) bool {
+	return scalar.EqualWithinAbsOrRel(a, b, 1e-10, 1e-10)
+}
@@ -0,0 +1,139 @@
+package deflect
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/floats/scalar"
+)
+
+func simplySupportedTestProblem(t *testing.T) Problem {
+	t.Helper()
+
+	nodes := []Node{{ID: "A"}, {ID: "C", X: 2}, {ID: "B", X: 4}}
+	hinges := map[Index]struct{}{}
+	ac, errAC := NewFrame2d("AC", &nodes[0], &nodes[1], &exampleMat, hinges)
+	cb, errCB := NewFrame2d("CB", &nodes[1], &nodes[2], &exampleMat, hinges)
+
+	if errAC != nil || errCB != nil {
+		t.Fatalf("Expected successful frame instantiation, got %v, %v", errAC, errCB)
+	}
+
+	return Problem{
+		Nodes:    nodes,
+		Elements: []Element{ac, cb},
+		Dirichlet: []NodalValue{
+			{Index: Index{NodalID: "A", Dof: Ux}, Value: 0},
+			{Index: Index{NodalID: "A", Dof: Uz}, Value: 0},
+			{Index: Index{NodalID: "B", Dof: Uz}, Value: 0},
+		},
+		// Must not affect the influence lines:
+		Neumann: []NodalValue{{Index: Index{NodalID: "C", Dof: Uz}, Value: -5e3}},
+	}
+}
+
+func TestInfluenceLinesSimplySupported(t *testing.T) {
+	p := simplySupportedTestProblem(t)
+	indices, err := NewEqLayout(&p)
+	if err != nil {
+		t.Fatalf("Expected valid equation layout, got %v", err)
+	}
+
+	load := MovingLoad{Path: []string{"AC", "CB"}, Kind: Uz, Step: 0.5}
+	quantities := []InfluenceQuantity{
+		ReactionInfluence(Index{NodalID: "A", Dof: Uz}),
+		InterpolationInfluence("CB", FctMy, 0),
+	}
+
+	solver := NewLinearProblemSolver(WithFactorizationReuse())
+	lines, err := ComputeInfluenceLines(&p, indices, solver, NewCholeskySolver(), load, quantities)
+	if err != nil {
+		t.Fatalf("Expected successful influence line computation, got %v", err)
+	}
+
+	if len(lines.Positions) != 9 {
+		t.Fatalf("Expected 9 unit load positions, got %v", lines.Positions)
+	}
+
+	for j, s := range lines.Positions {
+		reaction := (4 - s) / 4
+		moment := min(s, 4-s) / 2
+
+		if !scalar.EqualWithinAbs(lines.Values[0][j], reaction, 1e-10) {
+			t.Errorf("Expected reaction %v at %v, got %v", reaction, s, lines.Values[0][j])
+		}
+
+		if !scalar.EqualWithinAbs(lines.Values[1][j], moment, 1e-10) {
+			t.Errorf("Expected moment %v at %v, got %v", moment, s, lines.Values[1][j])
+		}
+	}
+
+	if v := lines.At(1, 1.25); !scalar.EqualWithinAbs(v, 0.625, 1e-10) {
+		t.Errorf("Expected interpolated moment 0.625, got %v", v)
+	}
+
+	if v := lines.At(0, 4.5); v != 0 {
+		t.Errorf("Expected zero influence outside of the path, got %v", v)
+	}
+}
+
+func TestInfluenceLinesLoadTrain(t *testing.T) {
+	p := simplySupportedTestProblem(t)
+	indices, err := NewEqLayout(&p)
+	if err != nil {
+		t.Fatalf("Expected valid equation layout, got %v", err)
+	}
+
+	load := MovingLoad{
+		Path:  []string{"AC", "CB"},
+		Kind:  Uz,
+		Axles: []Axle{{Offset: 0, Load: 1}, {Offset: 1, Load: 1}},
+		Step:  0.5,
+	}
+	quantities := []InfluenceQuantity{
+		ReactionInfluence(Index{NodalID: "A", Dof: Uz}),
+		InterpolationInfluence("AC", FctMy, 2),
+	}
+
+	lines, err := ComputeInfluenceLines(
+		&p, indices, NewLinearProblemSolver(), NewCholeskySolver(), load, quantities)
+	if err != nil {
+		t.Fatalf("Expected successful influence line computation, got %v", err)
+	}
+
+	if governing := lines.Max[0]; governing != (GoverningPosition{Position: 0, Value: 1.75}) {
+		t.Errorf("Expected max. reaction 1.75 at 0, got %v", governing)
+	}
+
+	governing, at := lines.Max[1], lines.Max[1].Position
+	if !scalar.EqualWithinAbs(governing.Value, 1.5, 1e-10) || (at != 1 && at != 2) {
+		t.Errorf("Expected max. moment 1.5 at 1 or 2, got %v", governing)
+	}
+
+	// The frames must be left without moving loads:
+	for _, e := range p.Elements {
+		if n := len(e.(*frame).beam.(*beam2d).loads); n != 0 {
+			t.Errorf("Expected no loads left on %v, got %v", e.ID(), n)
+		}
+	}
+}
+
+func TestInfluenceLinesInvalidInput(t *testing.T) {
+	p := simplySupportedTestProblem(t)
+	indices, _ := NewEqLayout(&p)
+	solver, strategy := NewLinearProblemSolver(), NewCholeskySolver()
+	invalid := []MovingLoad{
+		{Path: nil, Kind: Uz, Step: 1},
+		{Path: []string{"AC"}, Kind: Uz, Step: 0},
+		{Path: []string{"XY"}, Kind: Uz, Step: 1},
+		{Path: []string{"AC"}, Kind: Phix, Step: 1},
+		// Not connected, or traversed in the wrong direction:
+		{Path: []string{"CB", "AC"}, Kind: Uz, Step: 1},
+		{Path: []string{"AC", "AC"}, Kind: Uz, Step: 1},
+	}
+
+	for _, load := range invalid {
+		if _, err := ComputeInfluenceLines(&p, indices, solver, strategy, load, nil); err == nil {
+			t.Errorf("Expected failure for moving load %v", load)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package deflect
+
+import (
+	"fmt"
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+type ldl struct {
+	_ byte // Non-zero size, see cholesky
+}
+
+func (l *ldl) SolveLinearSystem(a mat.Symmetric, b, x *mat.VecDense) error {
+	factorization, err := l.Factorize(a)
+
+	if err != nil {
+		return err
+	}
+
+	return factorization.Solve(b, x)
+}
+
+// Factorize computes P·a·Pᵀ = L·D·Lᵀ with the pivoting strategy of Bunch and Kaufman, where P is a
+// permutation, L is unit lower triangular, and D is block diagonal with blocks of size one or two.
+// Gonum doesn't provide a symmetric indefinite factorization, hence this dense implementation.
+func (l *ldl) Factorize(a mat.Symmetric) (Factorization, error) {
+	// This threshold bounds the growth of the entries, see Bunch and Kaufman (1977).
+	alpha := (1 + math.Sqrt(17)) / 8
+	n := a.SymmetricDim()
+	f := &ldlFactorization{
+		lower:   mat.NewDense(n, n, nil),
+		diag:    make([]float64, n),
+		offDiag: make([]float64, n),
+		pair:    make([]bool, n),
+		perm:    make([]int, n),
+	}
+	w := f.lower
+	var largest float64
+
+	// The lower triangle of w holds the trailing submatrix that is still to be factorised, and it
+	// is successively overwritten by the columns of L.
+	for i := range n {
+		f.perm[i] = i
+
+		for j := range i + 1 {
+			w.Set(i, j, a.At(i, j))
+			largest = max(largest, math.Abs(a.At(i, j)))
+		}
+	}
+
+	at := func(i, j int) float64 { return w.At(max(i, j), min(i, j)) }
+	singular := float64(n) * largest * 1e-15
+
+	for k := 0; k < n; {
+		colmax, imax := 0.0, k
+
+		for i := k + 1; i < n; i++ {
+			if v := math.Abs(w.At(i, k)); v > colmax {
+				colmax, imax = v, i
+			}
+		}
+
+		absakk := math.Abs(w.At(k, k))
+
+		if max(absakk, colmax) <= singular {
+			return nil, fmt.Errorf("failed to compute LDLᵀ factorisation, singular in column %v", k)
+		}
+
+		pivot, size := k, 1
+
+		if absakk < alpha*colmax {
+			var rowmax float64
+
+			for j := k; j < n; j++ {
+				if j != imax {
+					rowmax = max(rowmax, math.Abs(at(imax, j)))
+				}
+			}
+
+			switch {
+			case absakk >= alpha*colmax*(colmax/rowmax):
+			case math.Abs(w.At(imax, imax)) >= alpha*rowmax:
+				pivot = imax
+			default:
+				pivot, size = imax, 2
+			}
+		}
+
+		f.swap(k+size-1, pivot)
+
+		if size == 1 {
+			d := w.At(k, k)
+
+			for i := k + 1; i < n; i++ {
+				for j := k + 1; j <= i; j++ {
+					w.Set(i, j, w.At(i, j)-w.At(i, k)*w.At(j, k)/d)
+				}
+			}
+
+			for i := k + 1; i < n; i++ {
+				w.Set(i, k, w.At(i, k)/d)
+			}
+
+			f.diag[k] = d
+			w.Set(k, k, 1)
+			k++
+			continue
+		}
+
+		d11, d21, d22 := w.At(k, k), w.At(k+1, k), w.At(k+1, k+1)
+		det := d11*d22 - d21*d21
+
+		// The trailing update subtracts [w_ik w_ik+1]·D⁻¹·[w_jk w_jk+1]ᵀ:
+		for i := k + 2; i < n; i++ {
+			lk := (w.At(i, k)*d22 - w.At(i, k+1)*d21) / det
+			lk1 := (w.At(i, k+1)*d11 - w.At(i, k)*d21) / det
+
+			for j := k + 2; j <= i; j++ {
+				w.Set(i, j, w.At(i, j)-lk*w.At(j, k)-lk1*w.At(j, k+1))
+			}
+		}
+
+		// Overwriting row i with [w_ik w_ik+1]·D⁻¹ is only possible now, since the update reads the
+		// rows j ≤ i.
+		for i := k + 2; i < n; i++ {
+			wk, wk1 := w.At(i, k), w.At(i, k+1)
+			w.Set(i, k, (wk*d22-wk1*d21)/det)
+			w.Set(i, k+1, (wk1*d11-wk*d21)/det)
+		}
+
+		f.diag[k], f.diag[k+1], f.offDiag[k], f.pair[k] = d11, d22, d21, true
+		w.Set(k, k, 1)
+		w.Set(k+1, k, 0)
+		w.Set(k+1, k+1, 1)
+		k += 2
+	}
+
+	return f, nil
+}
+
+type ldlFactorization struct {
+	// Unit lower triangular L, with arbitrary entries above the diagonal.
+	lower *mat.Dense
+	// Diagonal of D, and the entry below it for a block of size two that starts at the same index.
+	diag, offDiag []float64
+	pair          []bool
+	// The original index of every row of P·a·Pᵀ.
+	perm []int
+}
+
+// swap exchanges rows and columns p ≤ q in the lower triangle of the partially factorised matrix,
+// including the computed columns of L.
+func (f *ldlFactorization) swap(p, q int) {
+	if p == q {
+		return
+	}
+
+	w := f.lower
+	n, _ := w.Dims()
+	exchange := func(i1, j1, i2, j2 int) {
+		v := w.At(i1, j1)
+		w.Set(i1, j1, w.At(i2, j2))
+		w.Set(i2, j2, v)
+	}
+
+	for j := range p {
+		exchange(p, j, q, j)
+	}
+
+	for j := p + 1; j < q; j++ {
+		exchange(j, p, q, j)
+	}
+
+	for i := q + 1; i < n; i++ {
+		exchange(i, p, i, q)
+	}
+
+	exchange(p, p, q, q)
+	f.perm[p], f.perm[q] = f.perm[q], f.perm[p]
+}
+
+func (f *ldlFactorization) Solve(b, x *mat.VecDense) error {
+	n := len(f.perm)
+
+	if b.Len() != n || x.Len() != n {
+		return fmt.Errorf("can't solve LDLᵀ-factorised system of size %v for %v entries", n, b.Len())
+	}
+
+	l := f.lower
+	y := make([]float64, n)
+
+	// Solve L·z = P·b:
+	for i := range n {
+		y[i] = b.AtVec(f.perm[i])
+
+		for j := range i {
+			y[i] -= l.At(i, j) * y[j]
+		}
+	}
+
+	// Solve D·v = z:
+	for k := 0; k < n; {
+		if !f.pair[k] {
+			y[k] /= f.diag[k]
+			k++
+			continue
+		}
+
+		d11, d21, d22 := f.diag[k], f.offDiag[k], f.diag[k+1]
+		det := d11*d22 - d21*d21
+		y[k], y[k+1] = (d22*y[k]-d21*y[k+1])/det, (d11*y[k+1]-d21*y[k])/det
+		k += 2
+	}
+
+	// Solve Lᵀ·(P·x) = v:
+	for i := n - 1; i >= 0; i-- {
+		for j := i + 1; j < n; j++ {
+			y[i] -= l.At(j, i) * y[j]
+		}
+	}
+
+	for i := range n {
+		x.SetVec(f.perm[i], y[i])
+	}
+
+	return nil
+}
+
+// NewLDLSolver creates a solver for symmetric coefficient matrices that are not necessarily
+// positive definite, e.g. the saddle point systems when Dirichlet BCs are enforced with Lagrange
+// multipliers. Unlike [NewLUSolver], it exploits the symmetry and preserves it while pivoting. It
+// implements [Factorizer].
+func NewLDLSolver() EquationSolver {
+	return &ldl{}
+}
@@ -0,0 +1,71 @@
+package deflect
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestLDLSolveIndefinite(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	random := mat.NewSymDense(12, nil)
+
+	for i := range 12 {
+		for j := i; j < 12; j++ {
+			random.SetSym(i, j, rng.Float64()*2-1)
+		}
+	}
+
+	cases := []struct {
+		name string
+		a    *mat.SymDense
+	}{
+		// A saddle point system with zero diagonal entries, which requires 2x2 pivots:
+		{"saddle point", mat.NewSymDense(4, []float64{
+			4, -2, 1, 0,
+			-2, 4, 0, 1,
+			1, 0, 0, 0,
+			0, 1, 0, 0})},
+		{"zero diagonal", mat.NewSymDense(3, []float64{
+			0, 1, 2,
+			1, 0, 3,
+			2, 3, 0})},
+		{"random", random},
+	}
+
+	for _, c := range cases {
+		n := c.a.SymmetricDim()
+		expected := mat.NewVecDense(n, nil)
+
+		for i := range n {
+			expected.SetVec(i, float64(i+1))
+		}
+
+		b := mat.NewVecDense(n, nil)
+		b.MulVec(c.a, expected)
+		x := mat.NewVecDense(n, nil)
+
+		if err := NewLDLSolver().SolveLinearSystem(c.a, b, x); err != nil {
+			t.Fatalf("%v: expected Solve to succeed, got %v", c.name, err)
+		}
+
+		if !mat.EqualApprox(expected, x, 1e-10) {
+			t.Errorf("%v: expected solution vector\n%v\nbut got\n%v", c.name,
+				mat.Formatted(expected), mat.Formatted(x))
+		}
+	}
+}
+
+func TestLDLSolveSingularMatrix(t *testing.T) {
+	a := mat.NewSymDense(3, []float64{
+		1, 2, 3,
+		2, 4, 6,
+		3, 6, 0})
+	b := mat.NewVecDense(3, nil)
+	x := mat.NewVecDense(3, nil)
+
+	if err := NewLDLSolver().SolveLinearSystem(a, b, x); err == nil {
+		t.Errorf("Expected LDLᵀ Ax=b solution to fail with singular A")
+	}
+}
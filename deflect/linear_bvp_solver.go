@@ -1,20 +1,142 @@
 package deflect
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"math"
+	"reflect"
+	"runtime"
+	"slices"
+	"sync"
+	"time"
 
 	"gonum.org/v1/gonum/floats/scalar"
 	"gonum.org/v1/gonum/mat"
 )
 
-// NewLinearProblemSolver creates a linear solver for boundary value problems.
-func NewLinearProblemSolver() ProblemSolver {
-	return &linearSolver{}
+// NewLinearProblemSolver creates a linear solver for boundary value problems. By default,
+// Dirichlet BCs are enforced by partitioning the system of equations into constrained and free
+// degrees of freedom, which can be changed with the given options. The solver keeps buffers between
+// calls and is hence not safe for concurrent use, see [SolverPool]. It implements
+// [ContextProblemSolver].
+func NewLinearProblemSolver(options ...SolverOption) ProblemSolver {
+	s := &linearSolver{equilibriumTol: 1e-6}
+
+	for _, option := range options {
+		option(s)
+	}
+
+	return s
+}
+
+// SolverOption customises the behaviour of a solver created by [NewLinearProblemSolver].
+type SolverOption func(*linearSolver)
+
+// WithLagrangeMultipliers enforces Dirichlet BCs by augmenting the system of equations with one
+// Lagrange multiplier per constrained degree of freedom. The multipliers are the negative
+// reactions. The augmented coefficient matrix is symmetric, but indefinite, so the EquationSolver
+// passed to [ProblemSolver.Solve] must handle such matrices, e.g. [NewLDLSolver]. Constraints
+// implemented as Transformer are enforced through their Dirichlet BC in transformed coordinates,
+// so their constraint forces are reported directly, too.
+func WithLagrangeMultipliers() SolverOption {
+	return func(s *linearSolver) {
+		s.enforcement = lagrangeMultipliers
+	}
+}
+
+// WithPenalty enforces Dirichlet BCs by adding a large stiffness α to the diagonal of every
+// constrained degree of freedom, where α is the given factor times the largest diagonal entry of
+// the assembled coefficient matrix. Prescribed values are hence only met approximately, with a
+// relative error in the order of 1/factor, and so are the reactions. The coefficient matrix stays
+// positive definite. A non-positive factor selects a default of 1e8.
+func WithPenalty(factor float64) SolverOption {
+	return func(s *linearSolver) {
+		s.enforcement = penalty
+		s.penaltyFactor = factor
+
+		if factor <= 0 {
+			s.penaltyFactor = 1e8
+		}
+	}
+}
+
+// WithParallelAssembly assembles the elements concurrently with the given number of workers. Each
+// worker assembles a contiguous share of the elements into its own tangent and residual, which are
+// summed up afterwards. This requires element implementations to only read shared state in
+// Assemble. The memory overhead is one coefficient matrix per additional worker. A non-positive
+// number of workers selects runtime.GOMAXPROCS(0).
+func WithParallelAssembly(workers int) SolverOption {
+	return func(s *linearSolver) {
+		s.workers = workers
+
+		if workers <= 0 {
+			s.workers = runtime.GOMAXPROCS(0)
+		}
+	}
+}
+
+// WithEquilibriumTolerance sets the tolerance of the equilibrium check after every solve, see
+// [ProblemResult.Equilibrium]. If the sum of all loads and reactions relative to their magnitudes
+// exceeds the tolerance, the result carries a warning. The default is 1e-6, and a non-positive
+// tolerance disables the check.
+func WithEquilibriumTolerance(tol float64) SolverOption {
+	return func(s *linearSolver) {
+		s.equilibriumTol = tol
+	}
+}
+
+// WithFactorizationReuse keeps the factorization of the coefficient matrix when the EquationSolver
+// is a [Factorizer], and reuses it for subsequent solves with the same strategy instance, e.g. for
+// multiple load cases or the positions of a moving load. The caller asserts that the problem only
+// changes in its loads, i.e., nodal and element loads, settlements, and prescribed values. Other
+// changes, e.g. to elements or supports, require a solver without this option or a new one. Only
+// strategies that are pointers can be identified, others are factorised for every solve. Distinct
+// instances of a zero-size type may share a factorization, which the built-in strategies avoid.
+func WithFactorizationReuse() SolverOption {
+	return func(s *linearSolver) {
+		s.reuseFactorization = true
+	}
+}
+
+// WithObserver reports every completed phase of a solve to the given observer.
+func WithObserver(observer Observer) SolverOption {
+	return func(s *linearSolver) {
+		s.observer = observer
+	}
 }
 
+type enforcement int
+
+const (
+	partitioning enforcement = iota
+	lagrangeMultipliers
+	penalty
+)
+
 type linearSolver struct {
 	eqn              matrices
 	dim, constrained int
+	enforcement      enforcement
+	penaltyFactor    float64
+	// Number of assembly workers, and the tangent and residual buffers of all but the first one.
+	workers int
+	workerK []*mat.SymDense
+	workerR []*mat.VecDense
+	// Buffers for the augmented system of equations with Lagrange multipliers, or the penalised one.
+	augmented          mat.SymDense
+	augmentedRHS, dlam mat.VecDense
+	// The factorization of the last coefficient matrix, together with its dimension and the strategy
+	// that factorised it, if enabled, see solveSystem.
+	reuseFactorization bool
+	factorization      Factorization
+	factorisedDim      int
+	factorizer         Factorizer
+	// Relative tolerance of the equilibrium check, disabled if non-positive.
+	equilibriumTol float64
+	// Optional observer, and the start of the current phase, see phaseDone.
+	observer   Observer
+	phaseStart time.Time
 }
 
 type matrices struct {
@@ -31,41 +153,296 @@ func (s *linearSolver) Solve(
 	indices EqLayout,
 	strategy EquationSolver,
 ) (ProblemResult, error) {
+	return s.SolveContext(context.Background(), p, indices, strategy)
+}
+
+func (s *linearSolver) SolveContext(
+	ctx context.Context,
+	p *Problem,
+	indices EqLayout,
+	strategy EquationSolver,
+) (ProblemResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("solve cancelled before assembly: %w", err)
+	}
+
+	s.phaseStart = time.Now()
+
 	if err := s.initialise(indices.eqSize(), len(p.Dirichlet)); err != nil {
 		return nil, err
 	}
 
-	// Local typing shortcuts
-	k, r, d, scratch := s.eqn.k, s.eqn.r, s.eqn.d, s.eqn.scratch
-	k11, k12, k22 := s.eqn.k11, s.eqn.k12, s.eqn.k22
-	d1, d2, r1, r2 := s.eqn.d1, s.eqn.d2, s.eqn.r1, s.eqn.r2
+	s.phaseDone(PhaseLayout)
 
-	for _, e := range p.Elements {
-		e.Assemble(indices, k, r, d)
+	if err := s.assemble(ctx, p.Elements, indices); err != nil && ctx.Err() != nil {
+		return nil, fmt.Errorf("solve cancelled during assembly: %w", err)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to assemble global matrices: %w", err)
 	}
 
+	// Local typing shortcuts
+	k, r, d := s.eqn.k, s.eqn.r, s.eqn.d
+
 	var hasNonZeroDirichlet bool
 
 	for _, bc := range p.Dirichlet {
-		hasNonZeroDirichlet = hasNonZeroDirichlet || scalar.EqualWithinAbs(bc.Value, 0, 1e-12)
+		hasNonZeroDirichlet = hasNonZeroDirichlet || !scalar.EqualWithinAbs(bc.Value, 0, 1e-12)
 		d.SetVec(indices.mapOne(bc.Index), bc.Value)
 	}
 
+	for _, settlement := range p.Settlements {
+		i := indices.mapOne(settlement.Index)
+
+		if i >= s.constrained {
+			return nil, fmt.Errorf(
+				"settlement %v/%v requires a Dirichlet BC for the same index",
+				settlement.NodalID,
+				settlement.Dof,
+			)
+		}
+
+		hasNonZeroDirichlet = hasNonZeroDirichlet || !scalar.EqualWithinAbs(settlement.Value, 0, 1e-12)
+		d.SetVec(i, d.AtVec(i)+settlement.Value)
+	}
+
 	for _, bc := range p.Neumann {
 		i := indices.mapOne(bc.Index)
 		ri := r.AtVec(i)
 		r.SetVec(i, ri+bc.Value)
 	}
 
+	s.phaseDone(PhaseAssembly)
+
 	for _, transform := range p.EqTransforms {
 		transform.Pre(indices, k, r, d)
 	}
 
+	s.phaseDone(PhaseTransforms)
+
 	if err := indices.failure(); err != nil {
 		// Don't attempt to continue if something went wrong so far
 		return nil, fmt.Errorf("failed to assemble global matrices: %w", err)
 	}
 
+	var errSolve error
+	// The solution procedures overwrite parts of r, so keep the loads for the residual below.
+	loads := mat.VecDenseCopyOf(r)
+
+	switch s.enforcement {
+	case lagrangeMultipliers:
+		errSolve = s.solveWithLagrangeMultipliers(ctx, strategy)
+	case penalty:
+		errSolve = s.solveWithPenalty(ctx, strategy)
+	default:
+		errSolve = s.solvePartitioned(ctx, strategy, hasNonZeroDirichlet)
+	}
+
+	if errSolve != nil {
+		return nil, fmt.Errorf("failed to solve assembled linear system: %w", errSolve)
+	}
+
+	residual, scale := residualAndScale(s.eqn.k, s.eqn.d, loads)
+	dResidual := mat.VecDenseCopyOf(d)
+	work := supportWork(r, d, scale, s.constrained)
+
+	// Transformations compose, so they are undone in reverse order:
+	for i := len(p.EqTransforms) - 1; i >= 0; i-- {
+		transform := p.EqTransforms[i]
+		transform.Post(indices, r, d)
+		// The residual needs its own copy of d, since Post transforms both vectors:
+		transform.Post(indices, residual, dResidual)
+	}
+
+	err := indices.flushFailure()
+
+	// The result gets its own copies, so that it stays valid when the solver is reused.
+	result := &solverResult{
+		total:       s.dim,
+		net:         s.dim - s.constrained,
+		d:           mat.VecDenseCopyOf(s.eqn.d),
+		r:           mat.VecDenseCopyOf(s.eqn.r),
+		residual:    residual,
+		scale:       scale,
+		supportWork: work,
+		indices:     indices,
+		nodes:       slices.Clone(p.Nodes),
+		elements:    p.Elements,
+		neumann:     slices.Clone(p.Neumann),
+	}
+
+	for _, transform := range p.EqTransforms {
+		if support, ok := transform.(*SkewedSupport); ok {
+			result.skewed = append(result.skewed, support)
+		}
+	}
+
+	if s.equilibriumTol > 0 {
+		result.warnings = checkEquilibrium(result, s.equilibriumTol)
+	}
+
+	s.phaseDone(PhasePostProcessing)
+
+	return result, err
+}
+
+// residualAndScale returns k·d - r for the solution d and the loads r, and the magnitudes
+// Σ|k_ij·d_j| + |r_i| of its terms. The residual is the reaction for constrained degrees of
+// freedom, and zero up to round-off for free ones, which the magnitudes are a scale for. Unlike
+// the reactions of a solved system, it doesn't contain the loads of free degrees of freedom.
+func residualAndScale(k mat.Symmetric, d, r *mat.VecDense) (residual, scale *mat.VecDense) {
+	n := d.Len()
+	residual, scale = mat.NewVecDense(n, nil), mat.NewVecDense(n, nil)
+
+	for i := range n {
+		value, magnitude := -r.AtVec(i), math.Abs(r.AtVec(i))
+
+		for j := range n {
+			term := k.At(i, j) * d.AtVec(j)
+			value += term
+			magnitude += math.Abs(term)
+		}
+
+		residual.SetVec(i, value)
+		scale.SetVec(i, magnitude)
+	}
+
+	return residual, scale
+}
+
+// phaseDone notifies the observer, if any, that the given phase is completed, and starts the next
+// phase.
+func (s *linearSolver) phaseDone(phase Phase) {
+	if s.observer == nil {
+		return
+	}
+
+	now := time.Now()
+	event := PhaseEvent{
+		Phase:    phase,
+		Duration: now.Sub(s.phaseStart),
+		Total:    s.dim,
+		Net:      s.dim - s.constrained,
+	}
+
+	s.observer.PhaseDone(event)
+	s.phaseStart = now
+}
+
+// assemble adds the tangent and residual contributions of all elements to the global matrices,
+// sequentially or with the configured number of workers. It stops early and returns the context's
+// error when it is cancelled.
+func (s *linearSolver) assemble(ctx context.Context, elements []Element, indices EqLayout) error {
+	k, r, d := s.eqn.k, s.eqn.r, s.eqn.d
+	workers := min(s.workers, len(elements))
+
+	if workers <= 1 {
+		return assembleChecked(ctx, elements, indices, k, r, d)
+	}
+
+	s.initialiseWorkerBuffers(workers - 1)
+
+	var wg sync.WaitGroup
+	chunk := (len(elements) + workers - 1) / workers
+	errs := make([]error, workers)
+
+	for w := range workers {
+		from, to := min(len(elements), w*chunk), min(len(elements), (w+1)*chunk)
+		kw, rw := k, r
+
+		if w > 0 {
+			// The first worker writes directly into the global matrices.
+			kw, rw = s.workerK[w-1], s.workerR[w-1]
+		}
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			errs[w] = assembleChecked(ctx, elements[from:to], indices, kw, rw, d)
+		}()
+	}
+
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	} else if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	for w := range workers - 1 {
+		k.AddSym(k, s.workerK[w])
+		r.AddVec(r, s.workerR[w])
+	}
+
+	return nil
+}
+
+// assembleChecked assembles the given elements sequentially and checks for cancellation every
+// cancellationInterval elements and at the end. Elements receive the layout by value, so their
+// lookup failures are lost. Their indices are hence looked up here, too, with a failure state of
+// this copy only, so that concurrent workers don't share it.
+func assembleChecked(
+	ctx context.Context,
+	elements []Element,
+	indices EqLayout,
+	k *mat.SymDense,
+	r, d *mat.VecDense,
+) error {
+	indices.failures, indices.failed = 0, nil
+	set := map[Index]struct{}{}
+
+	for i, e := range elements {
+		if i%cancellationInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+
+		clear(set)
+		e.Indices(set)
+
+		for index := range set {
+			indices.mapOne(index)
+		}
+
+		e.Assemble(indices, k, r, d)
+	}
+
+	return errors.Join(ctx.Err(), indices.failure())
+}
+
+// cancellationInterval is the number of elements assembled between two checks for cancellation.
+// Checking a context is cheap, but not free compared to the assembly of a single element.
+const cancellationInterval = 64
+
+// initialiseWorkerBuffers allocates or resets n tangent and residual buffers of the current size.
+func (s *linearSolver) initialiseWorkerBuffers(n int) {
+	for len(s.workerK) < n {
+		s.workerK = append(s.workerK, &mat.SymDense{})
+		s.workerR = append(s.workerR, &mat.VecDense{})
+	}
+
+	for w := range n {
+		s.workerK[w].Reset()
+		s.workerK[w].ReuseAsSym(s.dim)
+		s.workerR[w].Reset()
+		s.workerR[w].ReuseAsVec(s.dim)
+	}
+}
+
+// solvePartitioned solves the partitioned system of equations (see formMatrices) for the free
+// primary values and computes the reactions for the constrained ones.
+func (s *linearSolver) solvePartitioned(
+	ctx context.Context,
+	strategy EquationSolver,
+	hasNonZeroDirichlet bool,
+) error {
+	// Local typing shortcuts
+	scratch := s.eqn.scratch
+	k11, k12, k22 := s.eqn.k11, s.eqn.k12, s.eqn.k22
+	d1, d2, r1, r2 := s.eqn.d1, s.eqn.d2, s.eqn.r1, s.eqn.r2
+
 	s.extractK12()
 
 	if hasNonZeroDirichlet {
@@ -77,9 +454,8 @@ func (s *linearSolver) Solve(
 		r2.SubVec(r2, scratch)
 	}
 
-	errSolve := strategy.SolveLinearSystem(k22, r2, d2)
-	if errSolve != nil {
-		return nil, fmt.Errorf("failed to solve assembled linear system: %w", errSolve)
+	if err := s.solveSystem(ctx, strategy, k22, r2, d2); err != nil {
+		return err
 	}
 
 	// Computes reaction forces [r_1] = (-1)·[k_11 d_1 + k_12 d_2] for Dirichlet-constrained dofs:
@@ -91,20 +467,176 @@ func (s *linearSolver) Solve(
 	scratch.MulVec(k11, d1)
 	r1.AddVec(r1, scratch)
 
-	for _, transform := range p.EqTransforms {
-		transform.Post(indices, r, d)
+	return nil
+}
+
+// solveWithLagrangeMultipliers solves the augmented system
+//
+//	⎡k  cᵀ⎤⎡d⎤  ⎡r  ⎤
+//	⎣c  0 ⎦⎣λ⎦  ⎣d_1⎦
+//
+// where c selects the constrained degrees of freedom, and λ are the Lagrange multipliers. From
+// k·d - r = -cᵀ·λ follows that the reactions are r_1 = -λ. To keep the augmented matrix well
+// conditioned, the constraint rows are scaled with the largest diagonal entry β of k, i.e., c is
+// replaced by β·c and the prescribed values by β·d_1, which yields λ/β instead of λ.
+func (s *linearSolver) solveWithLagrangeMultipliers(
+	ctx context.Context,
+	strategy EquationSolver,
+) error {
+	k, r, d, r1 := s.eqn.k, s.eqn.r, s.eqn.d, s.eqn.r1
+	n := s.dim + s.constrained
+	beta := s.maxDiagonal()
+
+	s.augmented.Reset()
+	s.augmented.ReuseAsSym(n)
+	s.augmentedRHS.Reset()
+	s.augmentedRHS.ReuseAsVec(n)
+	s.dlam.Reset()
+	s.dlam.ReuseAsVec(n)
+
+	for i := range s.dim {
+		s.augmentedRHS.SetVec(i, r.AtVec(i))
+
+		for j := i; j < s.dim; j++ {
+			s.augmented.SetSym(i, j, k.At(i, j))
+		}
 	}
 
-	err := indices.flushFailure()
+	// The equation layout orders constrained degrees of freedom first, see formMatrices.
+	for i := range s.constrained {
+		s.augmented.SetSym(i, s.dim+i, beta)
+		s.augmentedRHS.SetVec(s.dim+i, beta*d.AtVec(i))
+	}
+
+	if err := s.solveSystem(ctx, strategy, &s.augmented, &s.augmentedRHS, &s.dlam); err != nil {
+		return err
+	}
+
+	d.CopyVec(s.dlam.SliceVec(0, s.dim))
+	r1.ScaleVec(-beta, s.dlam.SliceVec(s.dim, n))
+
+	return nil
+}
+
+// solveWithPenalty solves (k + α·cᵀ·c)·d = r + α·cᵀ·d̄_1, where c selects the constrained degrees
+// of freedom and d̄_1 are their prescribed values. Reactions are then computed as
+// r_1 = k_1·d - r_1, where k_1 are the constrained rows of the unpenalised tangent. This is
+// equivalent to α·(d̄_1 - d_1), but doesn't suffer from cancellation. The penalised tangent is a
+// copy, since adding and subtracting α in place would spoil the small entries of k_1.
+func (s *linearSolver) solveWithPenalty(ctx context.Context, strategy EquationSolver) error {
+	k, r, d, r1 := s.eqn.k, s.eqn.r, s.eqn.d, s.eqn.r1
+	alpha := s.penaltyFactor * s.maxDiagonal()
+	loads := mat.VecDenseCopyOf(r1)
+
+	s.augmented.Reset()
+	s.augmented.ReuseAsSym(s.dim)
+	s.augmented.CopySym(k)
+	s.augmentedRHS.Reset()
+	s.augmentedRHS.ReuseAsVec(s.dim)
+	s.augmentedRHS.CopyVec(r)
+
+	for i := range s.constrained {
+		s.augmented.SetSym(i, i, k.At(i, i)+alpha)
+		s.augmentedRHS.SetVec(i, r.AtVec(i)+alpha*d.AtVec(i))
+	}
+
+	if err := s.solveSystem(ctx, strategy, &s.augmented, &s.augmentedRHS, d); err != nil {
+		return err
+	}
+
+	for i := range s.constrained {
+		var kd float64
+
+		for j := range s.dim {
+			kd += k.At(i, j) * d.AtVec(j)
+		}
+
+		r1.SetVec(i, kd-loads.AtVec(i))
+	}
+
+	return nil
+}
+
+// solveSystem solves a·x = b with the given strategy. If the strategy is a [Factorizer] and
+// [WithFactorizationReuse] is given, the factorization is kept and reused as long as the strategy
+// and the dimension of the coefficient matrix don't change. A factorization can't be interrupted,
+// so cancellation is checked before and after it, while a [ContextEquationSolver] receives the
+// context.
+func (s *linearSolver) solveSystem(
+	ctx context.Context,
+	strategy EquationSolver,
+	a mat.Symmetric,
+	b, x *mat.VecDense,
+) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	factorizer, isFactorizer := strategy.(Factorizer)
+
+	if !isFactorizer {
+		var err error
+
+		if cancellable, ok := strategy.(ContextEquationSolver); ok {
+			err = cancellable.SolveLinearSystemContext(ctx, a, b, x)
+		} else {
+			err = strategy.SolveLinearSystem(a, b, x)
+		}
+
+		s.phaseDone(PhaseBackSubstitution)
+
+		return err
+	}
+
+	reuse := s.reuseFactorization && s.factorization != nil &&
+		s.factorisedDim == a.SymmetricDim() && sameFactorizer(s.factorizer, factorizer)
+
+	if !reuse {
+		factorization, err := factorizer.Factorize(a)
+
+		if err != nil {
+			s.factorization = nil
+			return err
+		}
+
+		s.factorization, s.factorisedDim, s.factorizer = factorization, a.SymmetricDim(), factorizer
+		s.phaseDone(PhaseFactorization)
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+
+	err := s.factorization.Solve(b, x)
+	s.phaseDone(PhaseBackSubstitution)
+
+	return err
+}
+
+// sameFactorizer returns true if a and b are the same pointer. Factorizers of other kinds aren't
+// necessarily comparable, so they are never considered the same. Pointers to zero-size types may
+// be equal for distinct instances, which custom strategies must avoid.
+func sameFactorizer(a, b Factorizer) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+
+	return va.Kind() == reflect.Pointer && vb.Kind() == reflect.Pointer && va.Type() == vb.Type() &&
+		va.Pointer() == vb.Pointer()
+}
+
+// maxDiagonal returns the largest absolute diagonal entry of the assembled coefficient matrix, or
+// one if all diagonal entries are zero.
+func (s *linearSolver) maxDiagonal() float64 {
+	var result float64
+
+	for i := range s.dim {
+		result = max(result, math.Abs(s.eqn.k.At(i, i)))
+	}
+
+	if result == 0 {
+		return 1
+	}
 
-	return &solverResult{
-		total:    s.dim,
-		net:      s.dim - s.constrained,
-		d:        s.eqn.d,
-		r:        s.eqn.r,
-		indices:  indices,
-		elements: p.Elements,
-	}, err
+	return result
 }
 
 func (s *linearSolver) initialise(dim, constrained int) error {
@@ -0,0 +1,368 @@
+package deflect
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"gonum.org/v1/gonum/floats/scalar"
+	"gonum.org/v1/gonum/mat"
+)
+
+func cantileverTestProblem(t *testing.T) Problem {
+	t.Helper()
+
+	nodes := []Node{{ID: "A"}, {ID: "B", X: 2}}
+	beam, err := NewFrame2d("AB", &nodes[0], &nodes[1], &exampleMat, map[Index]struct{}{})
+
+	if err != nil {
+		t.Fatalf("Expected successful frame instantiation, got %v", err)
+	}
+
+	return Problem{
+		Nodes:    nodes,
+		Elements: []Element{beam},
+		Dirichlet: []NodalValue{
+			{Index: Index{NodalID: "A", Dof: Ux}, Value: 0},
+			{Index: Index{NodalID: "A", Dof: Uz}, Value: 0.001},
+			{Index: Index{NodalID: "A", Dof: Phiy}, Value: 0},
+		},
+		Neumann: []NodalValue{
+			{Index: Index{NodalID: "B", Dof: Uz}, Value: 1e3},
+			{Index: Index{NodalID: "B", Dof: Ux}, Value: -2e3},
+		},
+	}
+}
+
+func TestConstraintEnforcementsAgree(t *testing.T) {
+	cases := []struct {
+		name      string
+		solver    ProblemSolver
+		strategy  EquationSolver
+		tolerance float64
+	}{
+		{"lagrange", NewLinearProblemSolver(WithLagrangeMultipliers()), NewLDLSolver(), 1e-10},
+		{"lagrange-lu", NewLinearProblemSolver(WithLagrangeMultipliers()), NewLUSolver(), 1e-10},
+		{"penalty", NewLinearProblemSolver(WithPenalty(1e6)), NewCholeskySolver(), 1e-5},
+		{"default-penalty", NewLinearProblemSolver(WithPenalty(0)), NewCholeskySolver(), 1e-5},
+	}
+
+	reference := cantileverTestProblem(t)
+	indices, err := NewEqLayout(&reference)
+	if err != nil {
+		t.Fatalf("Expected valid equation layout, got %v", err)
+	}
+
+	expected, err := NewLinearProblemSolver().Solve(&reference, indices, NewCholeskySolver())
+	if err != nil {
+		t.Fatalf("Expected successful reference solution, got %v", err)
+	}
+
+	primary := expected.PrimaryAll()
+	reactions := expected.ReactionAll()[:len(reference.Dirichlet)]
+
+	for _, c := range cases {
+		p := cantileverTestProblem(t)
+		result, err := c.solver.Solve(&p, indices, c.strategy)
+
+		if err != nil {
+			t.Errorf("%v: expected successful solution, got %v", c.name, err)
+			continue
+		}
+
+		for _, want := range primary {
+			got, _ := result.Primary(want.Index)
+			if !scalar.EqualWithinAbsOrRel(want.Value, got.Value, c.tolerance, c.tolerance) {
+				t.Errorf("%v: expected primary %v, got %v", c.name, want, got)
+			}
+		}
+
+		for _, want := range reactions {
+			got, _ := result.Reaction(want.Index)
+			if !scalar.EqualWithinAbsOrRel(want.Value, got.Value, c.tolerance, c.tolerance) {
+				t.Errorf("%v: expected reaction %v, got %v", c.name, want, got)
+			}
+		}
+	}
+}
+
+func TestLUSolveIndefinite(t *testing.T) {
+	// A saddle point matrix that Cholesky can't handle.
+	a := mat.NewSymDense(3, []float64{
+		2, 1, 1,
+		1, 3, 0,
+		1, 0, 0})
+	b := mat.NewVecDense(3, []float64{1, 2, 0.5})
+	x := mat.NewVecDense(3, nil)
+
+	if err := NewLUSolver().SolveLinearSystem(a, b, x); err != nil {
+		t.Fatalf("Expected LU solve to succeed, got %v", err)
+	}
+
+	var check mat.VecDense
+	check.MulVec(a, x)
+
+	if !mat.EqualApprox(&check, b, 1e-12) {
+		t.Errorf("Expected a·x = b, got\n%v", mat.Formatted(&check))
+	}
+
+	if err := NewCholeskySolver().SolveLinearSystem(a, b, x); err == nil {
+		t.Errorf("Expected Cholesky solve of indefinite matrix to fail")
+	}
+}
+
+type countingFactorizer struct {
+	cholesky
+	count int
+}
+
+func (c *countingFactorizer) Factorize(a mat.Symmetric) (Factorization, error) {
+	c.count++
+	return c.cholesky.Factorize(a)
+}
+
+func TestFactorizationReuse(t *testing.T) {
+	p := cantileverTestProblem(t)
+	indices, err := NewEqLayout(&p)
+	if err != nil {
+		t.Fatalf("Expected valid equation layout, got %v", err)
+	}
+
+	solver, strategy := NewLinearProblemSolver(WithFactorizationReuse()), &countingFactorizer{}
+	tip := Index{NodalID: "B", Dof: Uz}
+	solve := func() float64 {
+		result, err := solver.Solve(&p, indices, strategy)
+		if err != nil {
+			t.Fatalf("Expected successful solution, got %v", err)
+		}
+
+		uz, _ := result.Primary(tip)
+		return uz.Value
+	}
+
+	first := solve()
+	p.Neumann[0].Value *= 2
+	second := solve()
+
+	if strategy.count != 1 {
+		t.Errorf("Expected a single factorization for two load cases, got %v", strategy.count)
+	}
+
+	if scalar.EqualWithinAbs(first, second, 1e-12) {
+		t.Errorf("Expected different solutions for different loads, got %v and %v", first, second)
+	}
+
+	load, _ := NewElementConcentratedLoad(Uz, 1, 1e3)
+	p.Elements[0].AddLoad(load)
+	solve()
+
+	if strategy.count != 1 {
+		t.Errorf("Expected element loads not to require factorization, got %v", strategy.count)
+	}
+
+	p.Elements[0].RemoveLoad(load)
+	other := NewLinearProblemSolver(WithPenalty(0), WithFactorizationReuse())
+	_, _ = other.Solve(&p, indices, strategy)
+	_, _ = solver.Solve(&p, indices, strategy)
+
+	if strategy.count != 2 {
+		t.Errorf("Expected a factorization per distinct solver, got %v", strategy.count)
+	}
+
+	replacement := &countingFactorizer{}
+	_, _ = solver.Solve(&p, indices, replacement)
+
+	if replacement.count != 1 {
+		t.Errorf("Expected a new factorization with a new strategy, got %v", replacement.count)
+	}
+}
+
+func TestSameFactorizerDistinguishesInstances(t *testing.T) {
+	for _, create := range []func() EquationSolver{NewCholeskySolver, NewLUSolver, NewLDLSolver} {
+		a, b := create().(Factorizer), create().(Factorizer)
+
+		if sameFactorizer(a, b) {
+			t.Errorf("Expected distinct instances of %T not to be the same factorizer", a)
+		}
+
+		if !sameFactorizer(a, a) {
+			t.Errorf("Expected an instance of %T to be the same factorizer as itself", a)
+		}
+	}
+}
+
+// valueFactorizer isn't comparable, so the solver can't tell whether it factorised the matrix.
+type valueFactorizer struct {
+	counts []int
+}
+
+func (v valueFactorizer) SolveLinearSystem(a mat.Symmetric, b, x *mat.VecDense) error {
+	return NewCholeskySolver().SolveLinearSystem(a, b, x)
+}
+
+func (v valueFactorizer) Factorize(a mat.Symmetric) (Factorization, error) {
+	v.counts[0]++
+	return NewCholeskySolver().(Factorizer).Factorize(a)
+}
+
+func TestFactorizationWithoutReuse(t *testing.T) {
+	p := cantileverTestProblem(t)
+	indices, _ := NewEqLayout(&p)
+	counting, value := &countingFactorizer{}, valueFactorizer{counts: []int{0}}
+	cases := []struct {
+		name     string
+		solver   ProblemSolver
+		strategy EquationSolver
+		count    func() int
+	}{
+		{"default", NewLinearProblemSolver(), counting, func() int { return counting.count }},
+		{
+			"not comparable",
+			NewLinearProblemSolver(WithFactorizationReuse()),
+			value,
+			func() int { return value.counts[0] },
+		},
+	}
+
+	for _, c := range cases {
+		for range 2 {
+			if _, err := c.solver.Solve(&p, indices, c.strategy); err != nil {
+				t.Fatalf("%v: expected successful solution, got %v", c.name, err)
+			}
+		}
+
+		if n := c.count(); n != 2 {
+			t.Errorf("%v: expected a factorization per solve, got %v", c.name, n)
+		}
+	}
+}
+
+func TestFactorizationMultipleRHS(t *testing.T) {
+	a := mat.NewSymDense(2, []float64{4, 1, 1, 3})
+
+	for _, strategy := range []EquationSolver{NewCholeskySolver(), NewLUSolver(), NewLDLSolver()} {
+		factorization, err := strategy.(Factorizer).Factorize(a)
+		if err != nil {
+			t.Fatalf("Expected successful factorization, got %v", err)
+		}
+
+		rhs := []*mat.VecDense{
+			mat.NewVecDense(2, []float64{1, 2}),
+			mat.NewVecDense(2, []float64{-3, 0.5}),
+		}
+
+		for _, b := range rhs {
+			var x, check mat.VecDense
+			x.ReuseAsVec(2)
+
+			if err := factorization.Solve(b, &x); err != nil {
+				t.Fatalf("Expected successful solve, got %v", err)
+			}
+
+			check.MulVec(a, &x)
+
+			if !mat.EqualApprox(&check, b, 1e-12) {
+				t.Errorf("Expected a·x = b, got\n%v", mat.Formatted(&check))
+			}
+		}
+	}
+}
+
+func TestParallelAssemblyAgrees(t *testing.T) {
+	n := 25
+	nodes := make([]Node, n+1)
+	elements := make([]Element, n)
+	hinges := map[Index]struct{}{}
+
+	for i := range nodes {
+		nodes[i] = Node{ID: fmt.Sprintf("N%v", i), X: float64(i), Z: 0.1 * float64(i%3)}
+	}
+
+	for i := range elements {
+		id := fmt.Sprintf("E%v", i)
+		frame, err := NewFrame2d(id, &nodes[i], &nodes[i+1], &exampleMat, hinges)
+
+		if err != nil {
+			t.Fatalf("Expected successful frame instantiation, got %v", err)
+		}
+
+		frame.AddLoad(NewElementConstantLoad(Uz, 1e3*float64(i+1)))
+		elements[i] = frame
+	}
+
+	p := Problem{
+		Nodes:    nodes,
+		Elements: elements,
+		Dirichlet: []NodalValue{
+			{Index: Index{NodalID: "N0", Dof: Ux}, Value: 0},
+			{Index: Index{NodalID: "N0", Dof: Uz}, Value: 0},
+			{Index: Index{NodalID: "N0", Dof: Phiy}, Value: 0},
+			{Index: Index{NodalID: nodes[n].ID, Dof: Uz}, Value: 0},
+		},
+	}
+
+	indices, err := NewEqLayout(&p)
+	if err != nil {
+		t.Fatalf("Expected valid equation layout, got %v", err)
+	}
+
+	expected, err := NewLinearProblemSolver().Solve(&p, indices, NewCholeskySolver())
+	if err != nil {
+		t.Fatalf("Expected successful sequential solution, got %v", err)
+	}
+
+	for _, workers := range []int{0, 2, 7, 100} {
+		solver := NewLinearProblemSolver(WithParallelAssembly(workers))
+
+		// Solve twice to exercise the re-use of worker buffers.
+		for range 2 {
+			actual, err := solver.Solve(&p, indices, NewCholeskySolver())
+			if err != nil {
+				t.Fatalf("%v workers: expected successful solution, got %v", workers, err)
+			}
+
+			for i, want := range expected.PrimaryAll() {
+				got := actual.PrimaryAll()[i]
+				equal := scalar.EqualWithinAbsOrRel(got.Value, want.Value, 1e-10, 1e-10)
+
+				if got.Index != want.Index || !equal {
+					t.Errorf("%v workers: expected %v, got %v", workers, want, got)
+				}
+			}
+		}
+	}
+}
+
+func TestParallelAssemblyReportsLookupFailures(t *testing.T) {
+	n := 12
+	nodes, unknown := make([]Node, n+1), make([]Node, n+1)
+
+	for i := range nodes {
+		nodes[i] = Node{ID: fmt.Sprintf("N%v", i), X: float64(i)}
+		unknown[i] = Node{ID: fmt.Sprintf("X%v", i), X: float64(i)}
+	}
+
+	p := Problem{
+		Nodes:     nodes,
+		Elements:  continuousBeamFrames(t, nodes),
+		Dirichlet: superelementTestSupports(nodes[n].ID),
+	}
+
+	indices, err := NewEqLayout(&p)
+	if err != nil {
+		t.Fatalf("Expected valid equation layout, got %v", err)
+	}
+
+	// The layout doesn't know any of the nodes, so that every worker fails:
+	p.Elements = continuousBeamFrames(t, unknown)
+
+	for _, workers := range []int{1, 4} {
+		solver := NewLinearProblemSolver(WithParallelAssembly(workers))
+
+		if _, err := solver.Solve(&p, indices, NewCholeskySolver()); err == nil {
+			t.Errorf("%v workers: expected index lookup failure, got none", workers)
+		} else if !strings.Contains(err.Error(), "lookup failure") {
+			t.Errorf("%v workers: expected index lookup failure, got %v", workers, err)
+		}
+	}
+}
@@ -11,4 +11,6 @@ type LinearElastic struct {
 	PoissonsRatio float64
 	// Density given in kg/m^3. We assume the Density is constant across every element.
 	Density float64
+	// Yield strength in N/m^2 for stress checks. Zero if unknown.
+	YieldStrength float64
 }
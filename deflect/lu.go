@@ -0,0 +1,62 @@
+package deflect
+
+import (
+	"fmt"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+type lu struct {
+	_ byte // Non-zero size, see cholesky
+}
+
+func (l *lu) SolveLinearSystem(a mat.Symmetric, b, x *mat.VecDense) error {
+	factorization, err := l.Factorize(a)
+
+	if err != nil {
+		return err
+	}
+
+	return factorization.Solve(b, x)
+}
+
+func (l *lu) Factorize(a mat.Symmetric) (result Factorization, err error) {
+	// Same as for the Cholesky solver: turn Gonum panics into errors.
+	defer func() {
+		if r := recover(); r != nil {
+			result, err = nil, fmt.Errorf("could not LU-factorise coefficient matrix: %v", r)
+		}
+	}()
+
+	factorization := &luFactorization{}
+	factorization.decomposition.Factorize(a)
+
+	return factorization, nil
+}
+
+type luFactorization struct {
+	decomposition mat.LU
+}
+
+func (f *luFactorization) Solve(b, x *mat.VecDense) (result error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = fmt.Errorf("could not solve LU-factorised system: %v", r)
+		}
+	}()
+
+	if err := f.decomposition.SolveVecTo(x, false, b); err != nil {
+		cond := f.decomposition.Cond()
+		return fmt.Errorf("failed to solve LU-factorised system, condition = %v", cond)
+	}
+
+	return nil
+}
+
+// NewLUSolver creates an LU solver for symmetric coefficient matrices that are not necessarily
+// positive definite, e.g. the saddle point systems when Dirichlet BCs are enforced with Lagrange
+// multipliers. The decomposition uses partial pivoting and doesn't exploit the symmetry. It
+// implements [Factorizer].
+func NewLUSolver() EquationSolver {
+	return &lu{}
+}
@@ -0,0 +1,41 @@
+package deflect
+
+import "time"
+
+// Phase denotes a step of solving a boundary value problem, see [Observer].
+//
+//go:generate go run golang.org/x/tools/cmd/stringer -type=Phase -trimprefix Phase
+type Phase uint8
+
+// Pre-defined phases in the order they occur.
+const (
+	// PhaseLayout sets up the system of equations according to the equation layout.
+	PhaseLayout Phase = iota
+	// PhaseAssembly assembles the elements and applies nodal BCs.
+	PhaseAssembly
+	// PhaseTransforms runs the [Transformer.Pre] steps.
+	PhaseTransforms
+	// PhaseFactorization decomposes the coefficient matrix. It is skipped when a factorization is
+	// reused, and it is not reported when the EquationSolver isn't a [Factorizer].
+	PhaseFactorization
+	// PhaseBackSubstitution solves the factorised system, or the entire system when the
+	// EquationSolver isn't a [Factorizer].
+	PhaseBackSubstitution
+	// PhasePostProcessing computes reactions and runs the [Transformer.Post] steps.
+	PhasePostProcessing
+)
+
+// PhaseEvent describes a completed phase. The dimensions are the same as the ones returned by
+// [ProblemResult.Dimension].
+type PhaseEvent struct {
+	Phase      Phase
+	Duration   time.Duration
+	Total, Net int
+}
+
+// Observer is notified about every completed phase of a solve, e.g. to collect metrics. Any
+// preparation of the partitioned or augmented system of equations counts towards the phase that
+// follows it. Observers are called synchronously, so they should return quickly.
+type Observer interface {
+	PhaseDone(event PhaseEvent)
+}
@@ -0,0 +1,67 @@
+package deflect
+
+import (
+	"slices"
+	"testing"
+)
+
+type phaseRecorder struct {
+	events []PhaseEvent
+}
+
+func (pr *phaseRecorder) PhaseDone(event PhaseEvent) {
+	pr.events = append(pr.events, event)
+}
+
+func (pr *phaseRecorder) phases() []Phase {
+	return transform(func(e PhaseEvent) Phase { return e.Phase }, pr.events)
+}
+
+func TestObserverPhases(t *testing.T) {
+	p := cantileverTestProblem(t)
+	indices, _ := NewEqLayout(&p)
+	observer := &phaseRecorder{}
+	solver := NewLinearProblemSolver(WithObserver(observer), WithFactorizationReuse())
+	strategy := NewCholeskySolver()
+
+	result, err := solver.Solve(&p, indices, strategy)
+	if err != nil {
+		t.Fatalf("Expected successful solution, got %v", err)
+	}
+
+	expected := []Phase{
+		PhaseLayout,
+		PhaseAssembly,
+		PhaseTransforms,
+		PhaseFactorization,
+		PhaseBackSubstitution,
+		PhasePostProcessing,
+	}
+
+	if actual := observer.phases(); !slices.Equal(actual, expected) {
+		t.Errorf("Expected phases %v, got %v", expected, actual)
+	}
+
+	total, net := result.Dimension()
+
+	for _, event := range observer.events {
+		if event.Total != total || event.Net != net || event.Duration < 0 {
+			t.Errorf("Expected dimensions %v/%v and non-negative duration, got %v", total, net, event)
+		}
+	}
+
+	// The factorization is reused for a second solve:
+	observer.events = nil
+	_, _ = solver.Solve(&p, indices, strategy)
+	expected = slices.Delete(expected, 3, 4)
+
+	if actual := observer.phases(); !slices.Equal(actual, expected) {
+		t.Errorf("Expected phases %v, got %v", expected, actual)
+	}
+}
+
+func TestPhaseStringer(t *testing.T) {
+	if actual := PhaseBackSubstitution.String(); actual != "BackSubstitution" {
+		t.Errorf("Expected Phase's String() to be 'BackSubstitution', got '%v'", actual)
+	}
+}
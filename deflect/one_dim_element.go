@@ -3,6 +3,8 @@ package deflect
 import (
 	"fmt"
 	"slices"
+
+	"gonum.org/v1/gonum/spatial/r3"
 )
 
 func newOneDimElement(id string, n0, n1 *Node, material *Material) (oneDimElement, error) {
@@ -24,25 +26,74 @@ type oneDimElement struct {
 	n0, n1   *Node
 	material *Material
 	loads    []NeumannElementBC
+	// Global loads are decomposed into local parts when added, which are stored here, keyed by the
+	// global load, so that they can be removed again, see addGlobalPart.
+	globalParts map[NeumannElementBC][]NeumannElementBC
 }
 
+// AddLoad stores the given load, unless it is a partial load that starts at or beyond the element
+// end, since it can't act on the element.
 func (e *oneDimElement) AddLoad(bc NeumannElementBC) bool {
+	l := e.loadedLength()
+
+	switch load := bc.(type) {
+	case *neumannLinear:
+		if load.from >= l {
+			return false
+		}
+	case *neumannPolynomial:
+		if load.from >= l {
+			return false
+		}
+	}
+
 	e.loads = append(e.loads, bc)
 	return true
 }
 
+// RemoveLoad removes the given load, or the local parts of it if it is a global load.
 func (e *oneDimElement) RemoveLoad(bc NeumannElementBC) {
-	for {
-		i := slices.Index(e.loads, bc)
+	for _, load := range append(e.takeGlobalParts(bc), bc) {
+		for {
+			i := slices.Index(e.loads, load)
 
-		if i == -1 {
-			break
+			if i == -1 {
+				break
+			}
+
+			var zeroForGC NeumannElementBC
+			e.loads[i] = zeroForGC
+			e.loads = slices.Delete(e.loads, i, i+1)
 		}
+	}
+}
 
-		var zeroForGC NeumannElementBC
-		e.loads[i] = zeroForGC
-		e.loads = slices.Delete(e.loads, i, i+1)
+// addGlobalPart records that part has been added as a local part of the given global load, which
+// isn't stored itself.
+func (e *oneDimElement) addGlobalPart(global, part NeumannElementBC) {
+	if e.globalParts == nil {
+		e.globalParts = map[NeumannElementBC][]NeumannElementBC{}
 	}
+
+	e.globalParts[global] = append(e.globalParts[global], part)
+}
+
+// takeGlobalParts returns and forgets the local parts of the given global load.
+func (e *oneDimElement) takeGlobalParts(global NeumannElementBC) []NeumannElementBC {
+	parts := e.globalParts[global]
+	delete(e.globalParts, global)
+
+	return parts
+}
+
+// loadedLength returns the length of the domain that positions of element loads refer to.
+func (e *oneDimElement) loadedLength() float64 {
+	return length(e.n0, e.n1)
+}
+
+// sectionMaterial returns the material and cross section of the element, e.g. to compute stresses.
+func (e *oneDimElement) sectionMaterial() *Material {
+	return e.material
 }
 
 func (e *oneDimElement) NumNodes() uint {
@@ -52,3 +103,23 @@ func (e *oneDimElement) NumNodes() uint {
 func (e *oneDimElement) ID() string {
 	return e.id
 }
+
+// elementLoads returns the loads of the element, e.g. to compute their work.
+func (e *oneDimElement) elementLoads() []NeumannElementBC {
+	return e.loads
+}
+
+// axes returns the position of the first node and the local x- and z-axis in global coordinates.
+// The z-axis is perpendicular to the global y-axis, which is only meaningful for 2d elements.
+func (e *oneDimElement) axes() (origin, ex, ez r3.Vec) {
+	origin = r3.Vec{X: e.n0.X, Y: e.n0.Y, Z: e.n0.Z}
+	ex = r3.Unit(r3.Sub(r3.Vec{X: e.n1.X, Y: e.n1.Y, Z: e.n1.Z}, origin))
+	ez = r3.Cross(r3.Vec{Y: 1}, ex)
+
+	return origin, ex, ez
+}
+
+// endNodes returns the nodes that the element is defined by.
+func (e *oneDimElement) endNodes() (n0, n1 *Node) {
+	return e.n0, e.n1
+}
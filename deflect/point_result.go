@@ -0,0 +1,251 @@
+package deflect
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"gonum.org/v1/gonum/spatial/r3"
+)
+
+// PointResult holds the results of a solved problem at a point of an element in global
+// coordinates, see [ProblemResult.AtPosition] and [ProblemResult.AtPoint].
+type PointResult struct {
+	Element string
+	// X is the position along the element in its local co-system, Position the global point.
+	X        float64
+	Position r3.Vec
+	// Displacement and Rotation are the global translation and rotation of the point. At nodes, the
+	// displacement is the nodal one, while the rotation is the one of the element end, which differs
+	// from the nodal rotation at hinges. Trusses don't have any rotation.
+	Displacement, Rotation r3.Vec
+	// Force and Moment are the section forces on the cut face with the local x-axis as its outward
+	// normal, i.e., Nx·ex + Vz·ez and My about the local y-axis. Where they jump, the values right of
+	// the point are taken, except for the element end.
+	Force, Moment r3.Vec
+}
+
+// lineGeometry is implemented by elements with a straight axis between two nodes.
+type lineGeometry interface {
+	loadedLength() float64
+	axes() (origin, ex, ez r3.Vec)
+	endNodes() (n0, n1 *Node)
+}
+
+func (sr *solverResult) AtPosition(elmtID string, ratio, zeroTol float64) (PointResult, error) {
+	idx := slices.IndexFunc(sr.elements, func(e Element) bool { return e.ID() == elmtID })
+
+	if idx == -1 {
+		return PointResult{}, fmt.Errorf("no element with ID '%v' found", elmtID)
+	} else if ratio < 0 || ratio > 1 {
+		return PointResult{}, fmt.Errorf("relative position %v outside of [0, 1]", ratio)
+	}
+
+	geometry, ok := sr.elements[idx].(lineGeometry)
+	if !ok {
+		return PointResult{}, fmt.Errorf("element %v has no straight axis to evaluate", elmtID)
+	}
+
+	return sr.pointResult(sr.elements[idx], geometry, ratio*geometry.loadedLength(), zeroTol)
+}
+
+func (sr *solverResult) AtPoint(point r3.Vec, tol, zeroTol float64) ([]PointResult, error) {
+	result, err := sr.pointResultsAt(point, tol, zeroTol)
+
+	if result == nil && err == nil {
+		err = fmt.Errorf("no element passes %v within tolerance %v", point, tol)
+	}
+
+	return result, err
+}
+
+// pointResultsAt is AtPoint without an error when no element passes the point. Superelements
+// contribute the results of their condensed elements, except for instances, whose elements aren't
+// located at the nodes of the problem.
+func (sr *solverResult) pointResultsAt(point r3.Vec, tol, zeroTol float64) ([]PointResult, error) {
+	var result []PointResult
+	var err error
+
+	for _, e := range sr.elements {
+		if s, ok := e.(*Superelement); ok {
+			condensed, errCondensed := sr.pointResultsCondensed(s, point, tol, zeroTol)
+			result = append(result, condensed...)
+			err = errors.Join(err, errCondensed)
+			continue
+		}
+
+		geometry, ok := e.(lineGeometry)
+		if !ok {
+			continue
+		}
+
+		origin, ex, _ := geometry.axes()
+		l := geometry.loadedLength()
+		relative := r3.Sub(point, origin)
+		x := r3.Dot(relative, ex)
+
+		if x < -tol || x > l+tol || r3.Norm(r3.Sub(relative, r3.Scale(x, ex))) > tol {
+			continue
+		}
+
+		// Snap to the element ends, so that all elements at a node agree on its displacement:
+		if x <= tol {
+			x = 0
+		} else if x >= l-tol {
+			x = l
+		}
+
+		single, errSingle := sr.pointResult(e, geometry, x, zeroTol)
+		err = errors.Join(err, errSingle)
+
+		if errSingle == nil {
+			result = append(result, single)
+		}
+	}
+
+	return result, err
+}
+
+// pointResultsCondensed returns the point results of the elements that s condenses.
+func (sr *solverResult) pointResultsCondensed(
+	s *Superelement,
+	point r3.Vec,
+	tol, zeroTol float64,
+) ([]PointResult, error) {
+	if s.rename != nil {
+		return nil, fmt.Errorf("can't evaluate the elements of instance %v at a point", s.ID())
+	}
+
+	recovered, err := s.Recover(sr)
+	if err != nil {
+		return nil, err
+	}
+
+	return recovered.(*solverResult).pointResultsAt(point, tol, zeroTol)
+}
+
+// localFields holds everything that point results of an element are composed of, see
+// localFieldsOf. Absent interpolations, e.g. the bending moment of a truss, are nil.
+type localFields struct {
+	interpolations map[Fct]PolySequence
+	// The global translations of the end nodes, and whether the element ends are located at them.
+	u0, u1           r3.Vec
+	atNode0, atNode1 bool
+}
+
+// localFieldsOf retrieves the local interpolations of elmt and the displacements of its end nodes,
+// which are the same for all points of the element.
+func (sr *solverResult) localFieldsOf(
+	elmt Element,
+	geometry lineGeometry,
+	zeroTol float64,
+) (localFields, error) {
+	result := localFields{interpolations: map[Fct]PolySequence{}}
+
+	for _, fct := range [...]Fct{FctUx, FctUz, FctPhiy, FctNx, FctVz, FctMy} {
+		interpolation, err := sr.Interpolate(elmt.ID(), fct, zeroTol)
+		if err != nil {
+			return localFields{}, err
+		}
+
+		result.interpolations[fct] = interpolation.Piecewise
+	}
+
+	n0, n1 := geometry.endNodes()
+	result.u0, result.u1 = sr.nodalDisplacement(n0.ID), sr.nodalDisplacement(n1.ID)
+	result.atNode0, result.atNode1 = sr.isNodalPosition(n0), sr.isNodalPosition(n1)
+
+	return result, nil
+}
+
+// pointResult evaluates the local interpolations of elmt at x and transforms them to global
+// coordinates.
+func (sr *solverResult) pointResult(
+	elmt Element,
+	geometry lineGeometry,
+	x, zeroTol float64,
+) (PointResult, error) {
+	fields, err := sr.localFieldsOf(elmt, geometry, zeroTol)
+	if err != nil {
+		return PointResult{}, err
+	}
+
+	return pointResultOf(elmt, geometry, fields, x)
+}
+
+// pointResultOf is pointResult with the fields of the element retrieved upfront.
+func pointResultOf(
+	elmt Element,
+	geometry lineGeometry,
+	fields localFields,
+	x float64,
+) (PointResult, error) {
+	local := map[Fct]float64{}
+
+	for fct, interpolation := range fields.interpolations {
+		if interpolation == nil {
+			continue
+		}
+
+		value, err := evalRightOf(interpolation, x)
+		if err != nil {
+			return PointResult{}, fmt.Errorf("element %v: %w", elmt.ID(), err)
+		}
+
+		local[fct] = value
+	}
+
+	origin, ex, ez := geometry.axes()
+	l := geometry.loadedLength()
+	u0, u1 := fields.u0, fields.u1
+
+	// Without a transverse interpolation, e.g. for trusses, the element axis stays straight.
+	transverse := r3.Add(r3.Scale(1-x/l, u0), r3.Scale(x/l, u1))
+	transverse = r3.Sub(transverse, r3.Scale(r3.Dot(transverse, ex), ex))
+
+	if uz, ok := local[FctUz]; ok {
+		transverse = r3.Scale(uz, ez)
+	}
+
+	// Rotations and moments of the local co-system are both about the local y-axis.
+	ey := r3.Cross(ez, ex)
+	result := PointResult{
+		Element:      elmt.ID(),
+		X:            x,
+		Position:     r3.Add(origin, r3.Scale(x, ex)),
+		Displacement: r3.Add(r3.Scale(local[FctUx], ex), transverse),
+		Rotation:     r3.Scale(local[FctPhiy], ey),
+		Force:        r3.Add(r3.Scale(local[FctNx], ex), r3.Scale(local[FctVz], ez)),
+		Moment:       r3.Scale(local[FctMy], ey),
+	}
+
+	// The ends of offset frames aren't located at the nodes, and the interpolation holds there.
+	if fields.atNode0 && positionsEqual(x, 0) {
+		result.Displacement = u0
+	} else if fields.atNode1 && positionsEqual(x, l) {
+		result.Displacement = u1
+	}
+
+	return result, nil
+}
+
+// nodalDisplacement returns the global translation of the given node, where translations without a
+// degree of freedom are zero.
+func (sr *solverResult) nodalDisplacement(nodalID string) r3.Vec {
+	var result r3.Vec
+
+	for _, dof := range [...]Dof{Ux, Uy, Uz} {
+		if u, err := sr.Primary(Index{NodalID: nodalID, Dof: dof}); err == nil {
+			result = r3.Add(result, r3.Scale(u.Value, dofAxis(dof)))
+		}
+	}
+
+	return result
+}
+
+// isNodalPosition returns true if there is a node with the ID of n at the position of n.
+func (sr *solverResult) isNodalPosition(n *Node) bool {
+	return slices.ContainsFunc(sr.nodes, func(other Node) bool {
+		return other.ID == n.ID && other.X == n.X && other.Y == n.Y && other.Z == n.Z
+	})
+}
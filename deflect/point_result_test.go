@@ -0,0 +1,226 @@
+package deflect
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/floats/scalar"
+	"gonum.org/v1/gonum/spatial/r3"
+)
+
+func vecEqualWithinAbs(a, b r3.Vec, tol float64) bool {
+	return scalar.EqualWithinAbs(r3.Norm(r3.Sub(a, b)), 0, tol)
+}
+
+func TestPointResultsAtSharedNode(t *testing.T) {
+	p := simplySupportedTestProblem(t)
+	p.Elements[0].AddLoad(NewElementConstantLoad(Uz, 1e3))
+	result := solveTestProblem(t, &p)
+
+	points, err := result.AtPoint(r3.Vec{X: 2 + 1e-12}, 1e-9, 1e-10)
+
+	if err != nil {
+		t.Fatalf("Expected results at node C, got %v", err)
+	} else if len(points) != 2 || points[0].Element != "AC" || points[1].Element != "CB" {
+		t.Fatalf("Expected results of AC and CB, got %+v", points)
+	} else if points[0].X != 2 || points[1].X != 0 {
+		t.Errorf("Expected positions at the element ends, got %v and %v", points[0].X, points[1].X)
+	}
+
+	ux, errUx := result.Primary(Index{NodalID: "C", Dof: Ux})
+	uz, errUz := result.Primary(Index{NodalID: "C", Dof: Uz})
+	phiy, errPhiy := result.Primary(Index{NodalID: "C", Dof: Phiy})
+
+	if errUx != nil || errUz != nil || errPhiy != nil {
+		t.Fatalf("Expected nodal values at C, got %v, %v, %v", errUx, errUz, errPhiy)
+	}
+
+	expected := r3.Vec{X: ux.Value, Z: uz.Value}
+
+	for _, point := range points {
+		if point.Displacement != expected {
+			t.Errorf("%v: expected displacement %v, got %v", point.Element, expected, point.Displacement)
+		}
+
+		if !scalar.EqualWithinAbsOrRel(point.Rotation.Y, phiy.Value, 1e-12, 1e-10) {
+			t.Errorf("%v: expected nodal rotation %v, got %v", point.Element, phiy.Value, point.Rotation)
+		}
+	}
+
+	// Free body of node C: the section forces of both elements balance the nodal load.
+	nodalLoad := r3.Vec{Z: -5e3}
+
+	if diff := r3.Sub(points[0].Force, points[1].Force); !vecEqualWithinAbs(diff, nodalLoad, 1e-8) {
+		t.Errorf("Expected section forces to differ by the nodal load %v, got %v", nodalLoad, diff)
+	}
+
+	if !vecEqualWithinAbs(points[0].Moment, points[1].Moment, 1e-8) {
+		t.Errorf("Expected continuous moments, got %v and %v", points[0].Moment, points[1].Moment)
+	}
+}
+
+func TestPointResultAtPosition(t *testing.T) {
+	p := simplySupportedTestProblem(t)
+	p.Elements[0].AddLoad(NewElementConstantLoad(Uz, 1e3))
+	result := solveTestProblem(t, &p)
+
+	point, err := result.AtPosition("AC", 0.25, 1e-10)
+	if err != nil {
+		t.Fatalf("Expected result at a quarter of AC, got %v", err)
+	}
+
+	uz, errUz := result.Interpolate("AC", FctUz, 1e-10)
+	my, errMy := result.Interpolate("AC", FctMy, 1e-10)
+
+	if errUz != nil || errMy != nil {
+		t.Fatalf("Expected interpolations of AC, got %v, %v", errUz, errMy)
+	}
+
+	localUz, errUz := evalRightOf(uz.Piecewise, 0.5)
+	localMy, errMy := evalRightOf(my.Piecewise, 0.5)
+
+	if errUz != nil || errMy != nil {
+		t.Fatalf("Expected values at x = 0.5, got %v, %v", errUz, errMy)
+	}
+
+	// The local z-axis points downwards, and the local y-axis is opposite to the global one.
+	expectUz, expectMy := -localUz, -localMy
+
+	if point.Position != (r3.Vec{X: 0.5}) || point.X != 0.5 {
+		t.Errorf("Expected position 0.5, got %v at x = %v", point.Position, point.X)
+	}
+
+	if !scalar.EqualWithinAbsOrRel(point.Displacement.Z, expectUz, 1e-12, 1e-10) {
+		t.Errorf("Expected displacement %v, got %v", expectUz, point.Displacement)
+	}
+
+	if !scalar.EqualWithinAbsOrRel(point.Moment.Y, expectMy, 1e-8, 1e-10) {
+		t.Errorf("Expected moment %v, got %v", expectMy, point.Moment)
+	}
+
+	for _, invalid := range [...]struct {
+		id    string
+		ratio float64
+	}{{"AC", -0.1}, {"AC", 1.5}, {"XY", 0.5}} {
+		if _, err := result.AtPosition(invalid.id, invalid.ratio, 1e-10); err == nil {
+			t.Errorf("Expected error for %v at %v, got nil", invalid.id, invalid.ratio)
+		}
+	}
+
+	if _, err := result.AtPoint(r3.Vec{X: 1, Z: 0.1}, 1e-6, 1e-10); err == nil {
+		t.Errorf("Expected error for a point off all elements, got nil")
+	}
+
+	// Positions within round-off of the element end snap to the nodal displacement:
+	end, err := result.AtPosition("AC", 1-1e-15, 1e-10)
+	ux, errUx := result.Primary(Index{NodalID: "C", Dof: Ux})
+	uzC, errUzC := result.Primary(Index{NodalID: "C", Dof: Uz})
+
+	if err != nil || errUx != nil || errUzC != nil {
+		t.Fatalf("Expected results at the end of AC, got %v, %v, %v", err, errUx, errUzC)
+	} else if expected := (r3.Vec{X: ux.Value, Z: uzC.Value}); end.Displacement != expected {
+		t.Errorf("Expected nodal displacement %v, got %v", expected, end.Displacement)
+	}
+}
+
+func TestPointResultsWithSuperelement(t *testing.T) {
+	nodes := []Node{{ID: "N0"}, {ID: "N1", X: 1, Z: 0.2}, {ID: "N2", X: 2}}
+	full := Problem{
+		Nodes:     nodes,
+		Elements:  continuousBeamFrames(t, nodes),
+		Dirichlet: superelementTestSupports("N2"),
+	}
+	point := r3.Vec{X: 1, Z: 0.2}
+	expected, err := solveTestProblem(t, &full).AtPoint(point, 1e-9, 1e-10)
+	if err != nil {
+		t.Fatalf("Expected results at N1, got %v", err)
+	}
+
+	s, err := NewSuperelement("S", continuousBeamFrames(t, nodes), []string{"N0", "N2"})
+	if err != nil {
+		t.Fatalf("Expected successful condensation, got %v", err)
+	}
+
+	condensed := Problem{
+		Nodes:     []Node{nodes[0], nodes[2]},
+		Elements:  []Element{s},
+		Dirichlet: superelementTestSupports("N2"),
+	}
+	result := solveTestProblem(t, &condensed)
+	actual, err := result.AtPoint(point, 1e-9, 1e-10)
+
+	if err != nil {
+		t.Fatalf("Expected results of the condensed elements, got %v", err)
+	} else if len(actual) != len(expected) || len(actual) != 2 {
+		t.Fatalf("Expected %v results, got %+v", len(expected), actual)
+	}
+
+	for i, want := range expected {
+		got := actual[i]
+		sameDisplacement := vecEqualWithinAbs(got.Displacement, want.Displacement, 1e-12)
+
+		if got.Element != want.Element || !sameDisplacement ||
+			!vecEqualWithinAbs(got.Moment, want.Moment, 1e-8) {
+			t.Errorf("Expected %+v, got %+v", want, got)
+		}
+	}
+
+	instance, err := s.Instance("S1", map[string]string{"N0": "N0", "N2": "N2"})
+	if err != nil {
+		t.Fatalf("Expected successful instantiation, got %v", err)
+	}
+
+	condensed.Elements = []Element{instance}
+
+	if _, err := solveTestProblem(t, &condensed).AtPoint(point, 1e-9, 1e-10); err == nil {
+		t.Errorf("Expected error for the elements of an instance, got nil")
+	}
+}
+
+func TestPointResultTrussStaysStraight(t *testing.T) {
+	nodes := []Node{{ID: "A"}, {ID: "C", X: 3, Z: 4}, {ID: "B", X: 6}}
+	hinges := map[Index]struct{}{}
+	ac, errAC := NewTruss2d("AC", &nodes[0], &nodes[1], &exampleMat, hinges)
+	cb, errCB := NewTruss2d("CB", &nodes[1], &nodes[2], &exampleMat, hinges)
+
+	if errAC != nil || errCB != nil {
+		t.Fatalf("Expected successful truss instantiation, got %v, %v", errAC, errCB)
+	}
+
+	p := Problem{
+		Nodes:    nodes,
+		Elements: []Element{ac, cb},
+		Dirichlet: []NodalValue{
+			{Index: Index{NodalID: "A", Dof: Ux}, Value: 0},
+			{Index: Index{NodalID: "A", Dof: Uz}, Value: 0},
+			{Index: Index{NodalID: "B", Dof: Ux}, Value: 0},
+			{Index: Index{NodalID: "B", Dof: Uz}, Value: 0},
+		},
+		Neumann: []NodalValue{
+			{Index: Index{NodalID: "C", Dof: Ux}, Value: 2e3},
+			{Index: Index{NodalID: "C", Dof: Uz}, Value: -5e3},
+		},
+	}
+	result := solveTestProblem(t, &p)
+
+	end, errEnd := result.AtPosition("AC", 1, 1e-10)
+	middle, errMiddle := result.AtPosition("AC", 0.5, 1e-10)
+
+	if errEnd != nil || errMiddle != nil {
+		t.Fatalf("Expected results along AC, got %v, %v", errEnd, errMiddle)
+	}
+
+	expected := r3.Scale(0.5, end.Displacement)
+
+	if !vecEqualWithinAbs(middle.Displacement, expected, 1e-14) {
+		t.Errorf("Expected displacement %v in the middle, got %v", expected, middle.Displacement)
+	}
+
+	if middle.Rotation != (r3.Vec{}) || middle.Moment != (r3.Vec{}) {
+		t.Errorf("Expected no rotation and moment of a truss, got %+v", middle)
+	}
+
+	// The normal force acts along the element axis.
+	if r3.Norm(r3.Cross(middle.Force, r3.Vec{X: 3, Z: 4})) > 1e-8 {
+		t.Errorf("Expected axial section force, got %v", middle.Force)
+	}
+}
@@ -3,9 +3,11 @@ package deflect
 import (
 	"fmt"
 	"log"
+	"math"
 	"slices"
 
 	"gonum.org/v1/gonum/floats/scalar"
+	"gonum.org/v1/gonum/mat"
 )
 
 // PolyPiece describes a one-dimensional piecewise polynomial with non-negative exponents.
@@ -101,6 +103,235 @@ func (p *PolyPiece) integrate(constant float64) PolyPiece {
 	return integrated
 }
 
+// product returns p·q over the domain of p. The domain of q is ignored.
+func (p *PolyPiece) product(q *PolyPiece) PolyPiece {
+	result := PolyPiece{X0: p.X0, XE: p.XE, Coeff: make([]float64, len(p.Coeff)+len(q.Coeff)-1)}
+
+	for i, pi := range p.Coeff {
+		for j, qj := range q.Coeff {
+			result.Coeff[i+j] += pi * qj
+		}
+	}
+
+	return result
+}
+
+// definiteIntegral returns the integral of p over its domain.
+func (p *PolyPiece) definiteIntegral() float64 {
+	integrated := p.integrate(0)
+	value, err := integrated.Eval(integrated.XE)
+
+	if err != nil {
+		log.Printf("Bug: evaluating polynomial at upper boundary must not fail: %v", err)
+	}
+
+	return value
+}
+
+// Roots returns the real roots of p within its domain in ascending order, without duplicates.
+// Polynomials up to degree four are solved in closed form, higher degrees by the eigenvalues of the
+// companion matrix. All roots are polished with Newton iterations. A constant polynomial, including
+// a zero one, is considered to have no roots. Multiple roots are sensitive to round-off and can be
+// missed when the coefficients aren't exact, e.g. for a deflection that touches zero.
+func (p *PolyPiece) Roots() []float64 {
+	coeff := p.Coeff
+
+	for len(coeff) > 0 && coeff[len(coeff)-1] == 0 {
+		coeff = coeff[:len(coeff)-1]
+	}
+
+	var candidates []float64
+
+	switch len(coeff) {
+	case 0, 1:
+		return nil
+	case 2:
+		candidates = []float64{-coeff[0] / coeff[1]}
+	case 3:
+		candidates = quadraticRoots(coeff[2], coeff[1], coeff[0])
+	case 4:
+		candidates = cubicRoots(coeff[3], coeff[2], coeff[1], coeff[0])
+	case 5:
+		candidates = quarticRoots(coeff[4], coeff[3], coeff[2], coeff[1], coeff[0])
+	default:
+		candidates = companionRoots(coeff)
+	}
+
+	for i, x := range candidates {
+		candidates[i] = newtonPolish(coeff, x)
+	}
+
+	return p.rootsInDomain(candidates)
+}
+
+// rootsInDomain sorts the candidates and drops the ones outside of the domain or duplicates.
+func (p *PolyPiece) rootsInDomain(candidates []float64) []float64 {
+	tol := 1e-10 * max(1, math.Abs(p.X0), math.Abs(p.XE))
+	approxEq := func(a, b float64) bool { return scalar.EqualWithinAbs(a, b, tol) }
+	result := make([]float64, 0, len(candidates))
+
+	for _, x := range candidates {
+		if x >= p.X0-tol && x <= p.XE+tol {
+			result = append(result, min(max(x, p.X0), p.XE))
+		}
+	}
+
+	slices.Sort(result)
+
+	return slices.CompactFunc(result, approxEq)
+}
+
+// quadraticRoots returns the real roots of a·x² + b·x + c with a ≠ 0, avoiding cancellation.
+func quadraticRoots(a, b, c float64) []float64 {
+	discriminant := b*b - 4*a*c
+
+	if discriminant < 0 {
+		// Tolerate a slightly negative discriminant for a double root:
+		if -discriminant > 1e-12*b*b {
+			return nil
+		}
+
+		discriminant = 0
+	}
+
+	if discriminant == 0 {
+		return []float64{-b / (2 * a)}
+	}
+
+	q := -0.5 * (b + math.Copysign(math.Sqrt(discriminant), b))
+
+	if q == 0 {
+		return []float64{0}
+	}
+
+	return []float64{q / a, c / q}
+}
+
+// cubicRoots returns the real roots of a·x³ + b·x² + c·x + d with a ≠ 0. The depressed cubic
+// t³ + p·t + q with x = t - b/(3·a) is solved with the trigonometric method for three real roots,
+// and with Cardano's formula otherwise.
+func cubicRoots(a, b, c, d float64) []float64 {
+	b, c, d = b/a, c/a, d/a
+	shift := b / 3
+	p := c - b*shift
+	q := 2*shift*shift*shift - shift*c + d
+	discriminant := q*q/4 + p*p*p/27
+
+	if p == 0 && q == 0 {
+		return []float64{-shift}
+	} else if p < 0 && discriminant <= 1e-12*max(q*q/4, -p*p*p/27) {
+		r := 2 * math.Sqrt(-p/3)
+		phi := math.Acos(max(-1, min(1, 3*q/(p*r)))) / 3
+
+		return []float64{
+			r*math.Cos(phi) - shift,
+			r*math.Cos(phi-2*math.Pi/3) - shift,
+			r*math.Cos(phi-4*math.Pi/3) - shift,
+		}
+	}
+
+	sqrtD := math.Sqrt(max(0, discriminant))
+
+	return []float64{math.Cbrt(-q/2+sqrtD) + math.Cbrt(-q/2-sqrtD) - shift}
+}
+
+// quarticRoots returns the real roots of a·x⁴ + b·x³ + c·x² + d·x + e with a ≠ 0. The depressed
+// quartic y⁴ + p·y² + q·y + r with x = y - b/(4·a) is solved as a quadratic in y² if q vanishes,
+// and factorised into two quadratics by Ferrari's method otherwise.
+func quarticRoots(a, b, c, d, e float64) []float64 {
+	b, c, d, e = b/a, c/a, d/a, e/a
+	shift := b / 4
+	p := c - 6*shift*shift
+	q := d - 2*c*shift + 8*shift*shift*shift
+	r := e - d*shift + c*shift*shift - 3*shift*shift*shift*shift
+	var result []float64
+
+	if math.Abs(q) <= 1e-12*max(1, math.Pow(math.Abs(p), 1.5), math.Pow(math.Abs(r), 0.75)) {
+		for _, z := range quadraticRoots(1, p, r) {
+			if z >= 0 {
+				result = append(result, math.Sqrt(z)-shift, -math.Sqrt(z)-shift)
+			}
+		}
+
+		return result
+	}
+
+	// The resolvent cubic has a positive root m since q ≠ 0, take the largest one for accuracy.
+	m := slices.Max(cubicRoots(8, 8*p, 2*p*p-8*r, -q*q))
+	s := math.Sqrt(2 * m)
+
+	for _, sign := range [...]float64{1, -1} {
+		for _, y := range quadraticRoots(1, -sign*s, p/2+m+sign*q/(2*s)) {
+			result = append(result, y-shift)
+		}
+	}
+
+	return result
+}
+
+// companionRoots returns the real eigenvalues of the companion matrix of the polynomial with the
+// given coefficients, where the last one must be non-zero. The roots should be polished afterwards.
+func companionRoots(coeff []float64) []float64 {
+	n := len(coeff) - 1
+	companion := mat.NewDense(n, n, nil)
+
+	for i := range n {
+		companion.Set(i, n-1, -coeff[i]/coeff[n])
+
+		if i > 0 {
+			companion.Set(i, i-1, 1)
+		}
+	}
+
+	var eigen mat.Eigen
+
+	if ok := eigen.Factorize(companion, mat.EigenNone); !ok {
+		return nil
+	}
+
+	var result []float64
+
+	for _, value := range eigen.Values(nil) {
+		if math.Abs(imag(value)) <= 1e-7*max(1, math.Abs(real(value))) {
+			result = append(result, real(value))
+		}
+	}
+
+	return result
+}
+
+// newtonPolish improves the accuracy of the approximate root x of the given polynomial. Steps that
+// don't decrease the residual are rejected, e.g. close to multiple roots.
+func newtonPolish(coeff []float64, x float64) float64 {
+	eval := func(x float64) (y, dy float64) {
+		for i := len(coeff) - 1; i >= 0; i-- {
+			dy = dy*x + y
+			y = y*x + coeff[i]
+		}
+
+		return y, dy
+	}
+
+	y, dy := eval(x)
+
+	for range 3 {
+		if dy == 0 || y == 0 {
+			break
+		}
+
+		next := x - y/dy
+		yNext, dyNext := eval(next)
+
+		if math.Abs(yNext) >= math.Abs(y) {
+			break
+		}
+
+		x, y, dy = next, yNext, dyNext
+	}
+
+	return x
+}
+
 // PolySequence is a piecewise polynomial.
 type PolySequence []PolyPiece
 
@@ -121,6 +352,125 @@ func (ps PolySequence) IntervalIndex(x0, xE float64) int {
 	return -1
 }
 
+// Roots returns the real roots of all polynomials in ascending order, see [PolyPiece.Roots]. Roots
+// on the boundary between two intervals are only reported once. A sign change at a jump between
+// intervals, e.g. of the shear force below a concentrated load, is not a root.
+func (ps PolySequence) Roots() []float64 {
+	var result []float64
+
+	for i := range ps {
+		result = append(result, ps[i].Roots()...)
+	}
+
+	slices.Sort(result)
+
+	return slices.CompactFunc(result, func(a, b float64) bool {
+		return scalar.EqualWithinAbsOrRel(a, b, 1e-10, 1e-10)
+	})
+}
+
+// Add returns the sum of ps and other, which is defined on the union of the domains of both. The
+// result has a single polynomial per sub-interval, but may have trailing near-zero coefficients,
+// see [PolySequence.TrimTrailingZeros]. Both operands are unchanged.
+func (ps PolySequence) Add(other PolySequence) PolySequence {
+	return slices.Concat(ps, other).flatten()
+}
+
+// Sub returns the difference ps - other, see [PolySequence.Add].
+func (ps PolySequence) Sub(other PolySequence) PolySequence {
+	return ps.Add(other.Scale(-1))
+}
+
+// Scale returns a copy of ps with all coefficients multiplied by factor.
+func (ps PolySequence) Scale(factor float64) PolySequence {
+	result := make(PolySequence, len(ps))
+
+	for i, p := range ps {
+		result[i] = PolyPiece{X0: p.X0, XE: p.XE, Coeff: slices.Clone(p.Coeff)}
+	}
+
+	if factor == 0 {
+		for i := range result {
+			result[i].Coeff = []float64{0}
+		}
+	} else {
+		result.multiply(factor)
+	}
+
+	return result
+}
+
+// Multiply returns the product of ps and other, e.g. a bending moment times a virtual moment. The
+// product is only defined where both operands are, and has a polynomial per sub-interval on which
+// both are smooth. Both operands are unchanged.
+func (ps PolySequence) Multiply(other PolySequence) PolySequence {
+	xs := make([]float64, 0, 2*(len(ps)+len(other)))
+
+	for _, p := range slices.Concat(ps, other) {
+		xs = append(xs, p.X0, p.XE)
+	}
+
+	slices.Sort(xs)
+
+	approxEq := func(a, b float64) bool { return scalar.EqualWithinAbs(a, b, 1e-10) }
+	covering := func(seq PolySequence, x0, xE float64) int {
+		return slices.IndexFunc(seq, func(p PolyPiece) bool {
+			return (p.X0 < x0 || approxEq(p.X0, x0)) && (p.XE > xE || approxEq(p.XE, xE))
+		})
+	}
+
+	xs = slices.CompactFunc(xs, approxEq)
+	var result PolySequence
+
+	for i := 0; i < len(xs)-1; i++ {
+		x0, xE := xs[i], xs[i+1]
+		j, k := covering(ps, x0, xE), covering(other, x0, xE)
+
+		if j == -1 || k == -1 {
+			continue
+		}
+
+		product := ps[j].product(&other[k])
+		product.X0, product.XE = x0, xE
+		result = append(result, product)
+	}
+
+	return result
+}
+
+// Derive returns the derivative of ps, see [PolyPiece.derive]. The receiver is unchanged.
+func (ps PolySequence) Derive() PolySequence {
+	return transform(func(p PolyPiece) PolyPiece { return p.derive() }, ps)
+}
+
+// DefiniteIntegral returns the integral of ps over its entire domain.
+func (ps PolySequence) DefiniteIntegral() float64 {
+	var result float64
+
+	for i := range ps {
+		result += ps[i].definiteIntegral()
+	}
+
+	return result
+}
+
+// Restrict returns a copy of the polynomials of ps within [x0, xE], where the domains of the
+// polynomials that intersect with the boundaries are truncated. Polynomials that only touch the
+// interval are dropped.
+func (ps PolySequence) Restrict(x0, xE float64) PolySequence {
+	var result PolySequence
+
+	for _, p := range ps {
+		from, to := max(p.X0, x0), min(p.XE, xE)
+
+		if to-from > 1e-10 {
+			result = append(result, PolyPiece{X0: from, XE: to, Coeff: slices.Clone(p.Coeff)})
+		}
+	}
+
+	return result
+}
+
 // flatten combines the given, additive piecewise polynomials so that there is only a single
 // PolyPiece instance per sub-interval of the domain, and the sub-intervals do not overlap. The
 // number of returned piecewise polynomials can be larger or smaller than the number of given
@@ -235,12 +585,12 @@ func (ps PolySequence) TrimTrailingZeros(zeroTol float64) {
 	}
 }
 
-// integrate uses [PolyPiece.integrate] to integrate individual polynomials. The receiver is
+// Integrate uses [PolyPiece.integrate] to integrate individual polynomials. The receiver is
 // unchanged, and the integral is returned as a new object. The sequence of polynomials is expected
 // to have contiguous domains, i.e., gaps and overlaps must not exist between intervals. The
-// integration constant is used for first polynomial in the sequence, all other integration
-// constants are determined by evaluating the precedent polynomial.
-func (ps PolySequence) integrate(constant float64) PolySequence {
+// integration constant is the value of the integral at the start of the first polynomial in the
+// sequence, all other integration constants are determined by evaluating the precedent polynomial.
+func (ps PolySequence) Integrate(constant float64) PolySequence {
 	if len(ps) == 0 {
 		// Shouldn't be relevant in practice, still treat it gracefully.
 		return nil
@@ -349,3 +349,245 @@ func polyDataEqual(p0, p1 PolyPiece, tol float64) bool {
 
 	return sameX0 && sameXE && sameCoeff
 }
+
+func TestPolyPieceProductAndDefiniteIntegral(t *testing.T) {
+	cases := []struct {
+		p, q     []float64
+		x0, xE   float64
+		product  []float64
+		integral float64
+	}{
+		{p: []float64{2}, q: []float64{3}, x0: 0, xE: 2, product: []float64{6}, integral: 12},
+		{
+			p: []float64{1, 1}, q: []float64{-1, 1}, x0: 1, xE: 2,
+			product: []float64{-1, 0, 1}, integral: 4.0 / 3,
+		},
+		{
+			p: []float64{0, 2}, q: []float64{1, 0, 3}, x0: -1, xE: 1,
+			product: []float64{0, 2, 0, 6}, integral: 0,
+		},
+	}
+
+	for _, test := range cases {
+		p := PolyPiece{X0: test.x0, XE: test.xE, Coeff: test.p}
+		q := PolyPiece{Coeff: test.q}
+		actual := p.product(&q)
+
+		if actual.X0 != test.x0 || actual.XE != test.xE || !slices.Equal(actual.Coeff, test.product) {
+			t.Errorf("Expected product %v, got %v", test.product, actual)
+		}
+
+		integral := actual.definiteIntegral()
+
+		if !scalar.EqualWithinAbs(integral, test.integral, 1e-12) {
+			t.Errorf("Expected definite integral %v, got %v", test.integral, integral)
+		}
+	}
+}
+
+func TestPolyPieceRoots(t *testing.T) {
+	cases := []struct {
+		name   string
+		x0, xE float64
+		coeff  []float64
+		expect []float64
+	}{
+		{"constant", 0, 1, []float64{2}, nil},
+		{"zero", 0, 1, []float64{0}, nil},
+		{"linear", 0, 4, []float64{-3, 2}, []float64{1.5}},
+		{"linear-outside", 0, 1, []float64{-3, 2}, nil},
+		{"quadratic", -5, 5, []float64{-2, -1, 1}, []float64{-1, 2}},
+		{"quadratic-double", 0, 5, []float64{4, -4, 1}, []float64{2}},
+		{"quadratic-complex", -5, 5, []float64{1, 0, 1}, nil},
+		{"quadratic-zero-root", -1, 1, []float64{0, 0, 3}, []float64{0}},
+		{"boundaries", 1, 2, []float64{2, -3, 1}, []float64{1, 2}},
+		{"trailing-zero", 0, 4, []float64{-3, 2, 0}, []float64{1.5}},
+		// (x - 1)·(x - 2)·(x - 3)
+		{"cubic", 0, 10, []float64{-6, 11, -6, 1}, []float64{1, 2, 3}},
+		{"cubic-partial", 1.5, 10, []float64{-6, 11, -6, 1}, []float64{2, 3}},
+		{"cubic-single", -5, 5, []float64{-2, 1, 0, 1}, []float64{1}},
+		{"cubic-triple", -5, 5, []float64{-1, 3, -3, 1}, []float64{1}},
+		// (x - 1)·(x - 2)·(x + 1)·(x + 3)
+		{"quartic", -5, 5, []float64{6, -1, -7, 1, 1}, []float64{-3, -1, 1, 2}},
+		// (x² - 4)·(x² + 1), biquadratic
+		{"quartic-biquadratic", -5, 5, []float64{-4, 0, -3, 0, 1}, []float64{-2, 2}},
+		{"quartic-complex", -5, 5, []float64{2, 0, 3, 0, 1}, nil},
+		// (x - 0.5)²·(x² + 1)
+		{"quartic-double", 0, 5, []float64{0.25, -1, 1.25, -1, 1}, []float64{0.5}},
+		// (x² + 1)·(x - 0.5)·(x + 4)·x
+		{"quintic", -5, 5, []float64{0, -2, 3.5, -1, 3.5, 1}, []float64{-4, 0, 0.5}},
+	}
+
+	approxEq := func(a, b float64) bool { return scalar.EqualWithinAbs(a, b, 1e-10) }
+
+	for _, c := range cases {
+		p := PolyPiece{X0: c.x0, XE: c.xE, Coeff: c.coeff}
+		actual := p.Roots()
+
+		if !slices.EqualFunc(actual, c.expect, approxEq) {
+			t.Errorf("%v: expected roots %v, got %v", c.name, c.expect, actual)
+		}
+	}
+}
+
+func TestPolySequenceRoots(t *testing.T) {
+	ps := PolySequence{
+		{X0: 0, XE: 1, Coeff: []float64{1, -1}},
+		{X0: 1, XE: 3, Coeff: []float64{-3, 4, -1}},
+		{X0: 3, XE: 4, Coeff: []float64{2}},
+		{X0: 4, XE: 5, Coeff: []float64{-2}},
+	}
+	// The boundary root at 1 is shared by two pieces, and the jump at 4 doesn't count.
+	expect := []float64{1, 3}
+	actual := ps.Roots()
+
+	approxEq := func(a, b float64) bool { return scalar.EqualWithinAbs(a, b, 1e-10) }
+
+	if !slices.EqualFunc(actual, expect, approxEq) {
+		t.Errorf("Expected roots %v, got %v", expect, actual)
+	}
+}
+
+func TestPolySequenceArithmetic(t *testing.T) {
+	// x on [0, 2] and 1 - x on [2, 3]
+	p := PolySequence{
+		{X0: 0, XE: 2, Coeff: []float64{0, 1}},
+		{X0: 2, XE: 3, Coeff: []float64{1, -1}},
+	}
+	// 2 on [1, 4]
+	q := PolySequence{{X0: 1, XE: 4, Coeff: []float64{2}}}
+	original := slices.Clone(p[0].Coeff)
+
+	cases := []struct {
+		name   string
+		actual PolySequence
+		expect PolySequence
+	}{
+		{
+			name:   "add",
+			actual: p.Add(q),
+			expect: PolySequence{
+				{X0: 0, XE: 1, Coeff: []float64{0, 1}},
+				{X0: 1, XE: 2, Coeff: []float64{2, 1}},
+				{X0: 2, XE: 3, Coeff: []float64{3, -1}},
+				{X0: 3, XE: 4, Coeff: []float64{2}},
+			},
+		},
+		{
+			name:   "sub",
+			actual: p.Sub(q),
+			expect: PolySequence{
+				{X0: 0, XE: 1, Coeff: []float64{0, 1}},
+				{X0: 1, XE: 2, Coeff: []float64{-2, 1}},
+				{X0: 2, XE: 3, Coeff: []float64{-1, -1}},
+				{X0: 3, XE: 4, Coeff: []float64{-2}},
+			},
+		},
+		{
+			name:   "scale",
+			actual: p.Scale(-3),
+			expect: PolySequence{
+				{X0: 0, XE: 2, Coeff: []float64{0, -3}},
+				{X0: 2, XE: 3, Coeff: []float64{-3, 3}},
+			},
+		},
+		{
+			name:   "scale-zero",
+			actual: p.Scale(0),
+			expect: PolySequence{
+				{X0: 0, XE: 2, Coeff: []float64{0}},
+				{X0: 2, XE: 3, Coeff: []float64{0}},
+			},
+		},
+		{
+			name:   "multiply",
+			actual: p.Multiply(p.Add(q)),
+			expect: PolySequence{
+				{X0: 0, XE: 1, Coeff: []float64{0, 0, 1}},
+				{X0: 1, XE: 2, Coeff: []float64{0, 2, 1}},
+				{X0: 2, XE: 3, Coeff: []float64{3, -4, 1}},
+			},
+		},
+		{
+			name:   "multiply-partial-overlap",
+			actual: p.Multiply(q),
+			expect: PolySequence{
+				{X0: 1, XE: 2, Coeff: []float64{0, 2}},
+				{X0: 2, XE: 3, Coeff: []float64{2, -2}},
+			},
+		},
+		{
+			name:   "derive",
+			actual: p.Derive(),
+			expect: PolySequence{
+				{X0: 0, XE: 2, Coeff: []float64{1}},
+				{X0: 2, XE: 3, Coeff: []float64{-1}},
+			},
+		},
+		{
+			name:   "integrate",
+			actual: p.Integrate(1),
+			expect: PolySequence{
+				{X0: 0, XE: 2, Coeff: []float64{1, 0, 0.5}},
+				{X0: 2, XE: 3, Coeff: []float64{3, 1, -0.5}},
+			},
+		},
+		{
+			name:   "restrict",
+			actual: p.Restrict(1, 2.5),
+			expect: PolySequence{
+				{X0: 1, XE: 2, Coeff: []float64{0, 1}},
+				{X0: 2, XE: 2.5, Coeff: []float64{1, -1}},
+			},
+		},
+		{
+			name:   "restrict-touching",
+			actual: p.Restrict(2, 5),
+			expect: PolySequence{{X0: 2, XE: 3, Coeff: []float64{1, -1}}},
+		},
+	}
+
+	approxEq := func(a, b float64) bool { return scalar.EqualWithinAbs(a, b, 1e-12) }
+	equalPiece := func(a, b PolyPiece) bool {
+		sameDomain := approxEq(a.X0, b.X0) && approxEq(a.XE, b.XE)
+		return sameDomain && slices.EqualFunc(a.Coeff, b.Coeff, approxEq)
+	}
+
+	for _, c := range cases {
+		if !slices.EqualFunc(c.actual, c.expect, equalPiece) {
+			t.Errorf("%v: expected %v, got %v", c.name, c.expect, c.actual)
+		}
+	}
+
+	if !slices.Equal(p[0].Coeff, original) {
+		t.Errorf("Expected operands to be unchanged, got %v", p)
+	}
+
+	// ∫x dx over [0, 2] plus ∫(1 - x) dx over [2, 3]
+	if integral := p.DefiniteIntegral(); !approxEq(integral, 2-1.5) {
+		t.Errorf("Expected definite integral 0.5, got %v", integral)
+	}
+}
+
+func TestPolySequenceSlopeFromDeflection(t *testing.T) {
+	p := cantileverTestProblem(t)
+	result := solveTestProblem(t, &p)
+	uz, errUz := result.Interpolate("AB", FctUz, 1e-12)
+	phiy, errPhiy := result.Interpolate("AB", FctPhiy, 1e-12)
+
+	if errUz != nil || errPhiy != nil {
+		t.Fatalf("Expected successful interpolations, got %v and %v", errUz, errPhiy)
+	}
+
+	// In the local co-system, we have d/dx uz(x) = -phiy(x).
+	slope := uz.Piecewise.Derive()
+	residual := slope.Add(phiy.Piecewise)
+
+	for _, x := range []float64{0, 0.5, 2} {
+		value, _ := evalRightOf(residual, x)
+
+		if !scalar.EqualWithinAbs(value, 0, 1e-12) {
+			t.Errorf("Expected slope and rotation to agree at %v, differ by %v", x, value)
+		}
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+
+	"gonum.org/v1/gonum/spatial/r3"
 )
 
 type matDescription struct {
@@ -25,6 +27,33 @@ type dirichletAngularLink struct {
 	Angle float64
 }
 
+type linearConstraintDescription struct {
+	Dof   string
+	Terms []struct {
+		Node        string
+		Dof         string
+		Coefficient float64
+	}
+	Constant float64
+}
+
+type skewedSupportDescription struct {
+	Restrained []string
+	// Exactly one of Direction (local z-axis) and Euler (yaw, pitch, roll) must be given.
+	Direction *[3]float64
+	Euler     *[3]float64
+}
+
+type rigidLinkDescription struct {
+	Master string
+	Dofs   []string
+}
+
+type rigidDiaphragmDescription struct {
+	Slaves []string
+	Dofs   []string
+}
+
 // neumannDescription is a simplistic sum type, where nothing enforces that is isn't used as a
 // product type. Should be ok, as it's used in a very small scope.
 type neumannDescription struct {
@@ -36,6 +65,8 @@ type neumannDescription struct {
 		Degree   string
 		Values   []float64
 		Position []float64
+		// Frame is one of "local" (the default), "global", or "projected".
+		Frame string
 	}
 }
 
@@ -67,6 +98,9 @@ type elmtDescription struct {
 	// Hinge maps node IDs to a sequence of string representations of degrees of freedoms that are
 	// hinged. Example: {"A": ["Ux"], "B", ["Uz", "Phiy"]}.
 	Hinges map[string][]string
+	// Offsets maps node IDs to the vector from that node to the respective end of the flexible part
+	// of the element, i.e., the rigid end zone. Example: {"A": [0.2, 0, 0]}.
+	Offsets map[string][3]float64
 }
 
 // ProblemFromJSON parses the given JSON data and constructs a boundary value problem from it.
@@ -78,6 +112,10 @@ func ProblemFromJSON(data []byte) (Problem, error) {
 		Elements     map[string]elmtDescription
 		Dirichlet    map[string][]nodalValues
 		Links        map[string][]dirichletAngularLink
+		Skewed       map[string][]skewedSupportDescription
+		Constraints  map[string][]linearConstraintDescription
+		Rigid        map[string][]rigidLinkDescription
+		Diaphragms   map[string]rigidDiaphragmDescription
 		Neumann      map[string][]neumannDescription
 	}
 
@@ -103,22 +141,37 @@ func ProblemFromJSON(data []byte) (Problem, error) {
 	}
 
 	dirichletBCs, errDirichlet := translateDirichletBCs(tmp.Dirichlet, nodes)
-	neumannNodalBCs, errNeumann0 := translateNodalNeumannBCs(tmp.Neumann, nodes)
+	neumannNodalBCs, settlements, errNeumann0 := translateNodalNeumannBCs(tmp.Neumann, nodes)
 	errNeumann1 := translateAndApplyElementNeumannBCs(tmp.Neumann, elements)
 	links, linkBCs, errLinks := translateAngularLinks(tmp.Links, nodes)
-
-	if err := errors.Join(errDirichlet, errNeumann0, errNeumann1, errLinks); err != nil {
+	skewed, skewedBCs, errSkewed := translateSkewedSupports(tmp.Skewed)
+	constraints, constraintBCs, errConstraints := translateConstraints(
+		tmp.Constraints,
+		tmp.Rigid,
+		tmp.Diaphragms,
+		nodes,
+	)
+
+	if err := errors.Join(
+		errDirichlet,
+		errNeumann0,
+		errNeumann1,
+		errLinks,
+		errSkewed,
+		errConstraints,
+	); err != nil {
 		return Problem{}, fmt.Errorf("construct BCs: %w", err)
-	} else if len(dirichletBCs)+len(linkBCs) == 0 {
+	} else if len(dirichletBCs)+len(linkBCs)+len(skewedBCs) == 0 {
 		return Problem{}, errors.New("can't construct a BVP with no Dirichlet BC")
 	}
 
 	result := Problem{
 		Nodes:        nodes,
 		Elements:     elements,
-		Dirichlet:    append(dirichletBCs, linkBCs...),
+		Dirichlet:    slices.Concat(dirichletBCs, linkBCs, skewedBCs, constraintBCs),
 		Neumann:      neumannNodalBCs,
-		EqTransforms: links,
+		EqTransforms: slices.Concat(constraints, links, skewed),
+		Settlements:  settlements,
 	}
 
 	return result, nil
@@ -218,12 +271,22 @@ func translateElement(
 		return nil, fmt.Errorf("hinge setup: %w", err)
 	}
 
+	offsets, err := formOffsets(from.Offsets, n0.ID, n1.ID)
+	if err != nil {
+		return nil, fmt.Errorf("rigid end offset setup: %w", err)
+	} else if len(from.Offsets) > 0 && from.Kind != "frame2d" {
+		return nil, fmt.Errorf("rigid end offsets are not supported by '%v' elements", from.Kind)
+	}
+
 	switch from.Kind {
 	case "truss2d":
 		return NewTruss2d(id, n0, n1, &mat, hinges)
 	case "truss3d":
 		return NewTruss3d(id, n0, n1, &mat, hinges)
 	case "frame2d":
+		if len(from.Offsets) > 0 {
+			return NewOffsetFrame2d(id, n0, n1, &mat, hinges, offsets)
+		}
 		return NewFrame2d(id, n0, n1, &mat, hinges)
 	}
 
@@ -304,6 +367,24 @@ func formHingeMap(from map[string][]string, n0, n1 string) (map[Index]struct{},
 	return hinges, dofs.FinaliseJoin(err)
 }
 
+func formOffsets(from map[string][3]float64, n0, n1 string) (offsets [2]r3.Vec, err error) {
+	for nodeID, o := range from {
+		switch nodeID {
+		case n0:
+			offsets[0] = r3.Vec{X: o[0], Y: o[1], Z: o[2]}
+		case n1:
+			offsets[1] = r3.Vec{X: o[0], Y: o[1], Z: o[2]}
+		default:
+			err = errors.Join(
+				err,
+				fmt.Errorf("offset node %v must be the element's nodes (%v or %v)", nodeID, n0, n1),
+			)
+		}
+	}
+
+	return offsets, err
+}
+
 func translateMaterials(from map[string]matDescription) (map[string]LinearElastic, error) {
 	materials := map[string]LinearElastic{}
 
@@ -324,6 +405,7 @@ func translateMaterials(from map[string]matDescription) (map[string]LinearElasti
 			YoungsModulus: E,
 			PoissonsRatio: nu,
 			Density:       rho,
+			YieldStrength: desc.Parameter["fy"], // Optional, zero if not given
 		}
 	}
 
@@ -405,10 +487,12 @@ func translateDirichletBCs(
 	return result, dofs.FinaliseJoin(nil)
 }
 
+// translateNodalNeumannBCs extracts nodal forces and moments, and settlements from the Neumann BC
+// descriptions. Settlements are declared like Dirichlet BCs (e.g. {"Uz": -0.01}), but act as loads.
 func translateNodalNeumannBCs(
 	from map[string][]neumannDescription,
 	nodes []Node,
-) ([]NodalValue, error) {
+) (forces, settlements []NodalValue, err error) {
 	dofs := dofLookup{
 		context: "construct nodal Neumann BC",
 		dofs: map[string]Dof{
@@ -419,24 +503,38 @@ func translateNodalNeumannBCs(
 			"Mz": Phiz,
 			"Mx": Phix,
 		}}
-	result := make([]NodalValue, 0, len(from))
+	settlementDofs := map[string]Dof{
+		"Ux":   Ux,
+		"Uz":   Uz,
+		"Uy":   Uy,
+		"Phiy": Phiy,
+		"Phiz": Phiz,
+		"Phix": Phix,
+	}
+	forces = make([]NodalValue, 0, len(from))
 
 	for nodeID, neumannDesc := range from {
 		for _, desc := range neumannDesc {
 			// When the object describes an element Neumann BC, the sequence is empty.
 			for dofName, value := range desc.Nodal {
+				if dof, ok := settlementDofs[dofName]; ok {
+					index := Index{NodalID: nodeID, Dof: dof}
+					settlements = append(settlements, NodalValue{Index: index, Value: value})
+					continue
+				}
+
 				dof, ok := dofs.Lookup(dofName)
 				if !ok {
 					continue
 				}
 
 				index := Index{NodalID: nodeID, Dof: dof}
-				result = append(result, NodalValue{Index: index, Value: value})
+				forces = append(forces, NodalValue{Index: index, Value: value})
 			}
 		}
 	}
 
-	return result, dofs.FinaliseJoin(nil)
+	return forces, settlements, dofs.FinaliseJoin(nil)
 }
 
 func translateAndApplyElementNeumannBCs(
@@ -472,6 +570,14 @@ func translateAndApplyElementNeumannBCs(
 	return err
 }
 
+// initialStrainLoads maps element load kinds to constructors of axial loads that aren't associated
+// with a degree of freedom.
+var initialStrainLoads = map[string]func(float64) NeumannElementBC{
+	"strain":    NewElementInitialStrain,
+	"misfit":    NewElementLengthMisfit,
+	"prestress": NewElementPrestress,
+}
+
 func translateElementNeumannBC(desc *neumannDescription) (NeumannElementBC, error) {
 	dofs := dofLookup{
 		context: "construct element Neumann BC",
@@ -491,6 +597,14 @@ func translateElementNeumannBC(desc *neumannDescription) (NeumannElementBC, erro
 		}}
 	var load NeumannElementBC
 
+	if initialStrain, ok := initialStrainLoads[desc.Element.Kind]; ok {
+		if desc.Element.Degree != "constant" || len(desc.Element.Values) != 1 {
+			return load, fmt.Errorf("%v load must be constant with 1 value", desc.Element.Kind)
+		}
+
+		return initialStrain(desc.Element.Values[0]), nil
+	}
+
 	kind, ok := dofs.Lookup(desc.Element.Kind)
 
 	if !ok {
@@ -509,21 +623,56 @@ func translateElementNeumannBC(desc *neumannDescription) (NeumannElementBC, erro
 			load, err = NewElementConcentratedLoad(kind, pos[0], values[0])
 		}
 	case "constant":
-		if numValues != 1 {
-			err = fmt.Errorf("constant load must have 1 value, got %v", numValues)
+		if numValues != 1 || (numPos != 0 && numPos != 2) {
+			err = fmt.Errorf(
+				"constant load needs 1 value and 0 or 2 positions, not %v and %v",
+				values,
+				pos,
+			)
+		} else if numPos == 2 {
+			load, err = NewElementPartialLinearLoad(kind, pos[0], pos[1], values[0], values[0])
 		} else {
 			load = NewElementConstantLoad(kind, values[0])
 		}
 	case "linear":
-		if numValues != 2 {
-			err = fmt.Errorf("linear load must have 2 values, got %v", numValues)
+		if numValues != 2 || (numPos != 0 && numPos != 2) {
+			err = fmt.Errorf(
+				"linear load needs 2 values and 0 or 2 positions, not %v and %v",
+				values,
+				pos,
+			)
+		} else if numPos == 2 {
+			load, err = NewElementPartialLinearLoad(kind, pos[0], pos[1], values[0], values[1])
 		} else {
 			load = NewElementLinearLoad(kind, values[0], values[1])
 		}
+	case "polynomial":
+		// The values are the coefficients in the element coordinate, in ascending order.
+		if numPos == 2 {
+			load, err = NewElementPartialPolynomialLoad(kind, pos[0], pos[1], values)
+		} else if numPos == 0 {
+			load, err = NewElementPolynomialLoad(kind, values)
+		} else {
+			err = fmt.Errorf("polynomial load needs 0 or 2 positions, not %v", pos)
+		}
 	default:
 		err = fmt.Errorf("unknown polynomial degree for Element BC '%v'", desc.Element.Degree)
 	}
 
+	if err != nil {
+		return load, err
+	}
+
+	switch desc.Element.Frame {
+	case "", "local":
+	case "global":
+		load, err = NewElementGlobalLoad(load)
+	case "projected":
+		load, err = NewElementProjectedLoad(load)
+	default:
+		err = fmt.Errorf("unknown frame for Element BC '%v'", desc.Element.Frame)
+	}
+
 	return load, err
 }
 
@@ -573,6 +722,169 @@ func translateAngularLinks(
 	return links, bcs, dofs.FinaliseJoin(nil)
 }
 
+func translateSkewedSupports(
+	from map[string][]skewedSupportDescription,
+) (supports []Transformer, bcs []NodalValue, err error) {
+	dofs := dofLookup{
+		context: "construct skewed support",
+		dofs: map[string]Dof{
+			"Ux":   Ux,
+			"Uz":   Uz,
+			"Uy":   Uy,
+			"Phiy": Phiy,
+			"Phiz": Phiz,
+			"Phix": Phix,
+		}}
+
+	for nodeID, descriptions := range from {
+		for _, desc := range descriptions {
+			restrained := make([]Dof, 0, len(desc.Restrained))
+
+			for _, name := range desc.Restrained {
+				if dof, ok := dofs.Lookup(name); ok {
+					restrained = append(restrained, dof)
+				}
+			}
+
+			var rot *r3.Mat
+			var errFrame error
+
+			switch {
+			case desc.Direction != nil && desc.Euler == nil:
+				direction := r3.Vec{X: desc.Direction[0], Y: desc.Direction[1], Z: desc.Direction[2]}
+				rot, errFrame = SkewedFrameFromDirection(direction)
+			case desc.Euler != nil && desc.Direction == nil:
+				rot = SkewedFrameFromEulerAngles(desc.Euler[0], desc.Euler[1], desc.Euler[2])
+			default:
+				errFrame = fmt.Errorf("skewed support at %v needs either direction or euler", nodeID)
+			}
+
+			if errFrame != nil {
+				err = errors.Join(err, errFrame)
+				continue
+			}
+
+			support, supportBCs, errSupport := NewSkewedSupport(nodeID, rot, restrained)
+
+			if errSupport != nil {
+				err = errors.Join(err, errSupport)
+				continue
+			}
+
+			supports = append(supports, support)
+			bcs = append(bcs, supportBCs...)
+		}
+	}
+
+	return supports, bcs, dofs.FinaliseJoin(err)
+}
+
+// translateConstraints instantiates linear multi-point constraints, rigid links, and rigid
+// diaphragms. The first two are keyed by the slave node ID, diaphragms by the master node ID.
+func translateConstraints(
+	linear map[string][]linearConstraintDescription,
+	rigid map[string][]rigidLinkDescription,
+	diaphragms map[string]rigidDiaphragmDescription,
+	nodes []Node,
+) (constraints []Transformer, bcs []NodalValue, err error) {
+	dofs := dofLookup{
+		context: "construct constraint",
+		dofs: map[string]Dof{
+			"Ux":   Ux,
+			"Uz":   Uz,
+			"Uy":   Uy,
+			"Phiy": Phiy,
+			"Phiz": Phiz,
+			"Phix": Phix,
+		}}
+
+	for slaveID, descriptions := range linear {
+		for _, desc := range descriptions {
+			dof, ok := dofs.Lookup(desc.Dof)
+			if !ok {
+				continue
+			}
+
+			masters := make([]LinearTerm, 0, len(desc.Terms))
+
+			for _, term := range desc.Terms {
+				if masterDof, ok := dofs.Lookup(term.Dof); ok {
+					index := Index{NodalID: term.Node, Dof: masterDof}
+					masters = append(masters, LinearTerm{Index: index, Coefficient: term.Coefficient})
+				}
+			}
+
+			slave := Index{NodalID: slaveID, Dof: dof}
+			transformer, bc, errSingle := NewLinearConstraint(slave, masters, desc.Constant)
+
+			if errSingle != nil {
+				err = errors.Join(err, errSingle)
+				continue
+			}
+
+			constraints = append(constraints, transformer)
+			bcs = append(bcs, bc)
+		}
+	}
+
+	for slaveID, descriptions := range rigid {
+		for _, desc := range descriptions {
+			linkDofs := make([]Dof, 0, len(desc.Dofs))
+
+			for _, name := range desc.Dofs {
+				if dof, ok := dofs.Lookup(name); ok {
+					linkDofs = append(linkDofs, dof)
+				}
+			}
+
+			master, errMaster := scanForNode(desc.Master, nodes)
+			slave, errSlave := scanForNode(slaveID, nodes)
+
+			if errNodes := errors.Join(errMaster, errSlave); errNodes != nil {
+				err = errors.Join(err, fmt.Errorf("construct rigid link: %w", errNodes))
+				continue
+			}
+
+			t, linkBCs, errLink := NewRigidLink(master, slave, linkDofs)
+			constraints = append(constraints, t...)
+			bcs = append(bcs, linkBCs...)
+			err = errors.Join(err, errLink)
+		}
+	}
+
+	for masterID, desc := range diaphragms {
+		diaphragmDofs := make([]Dof, 0, len(desc.Dofs))
+
+		for _, name := range desc.Dofs {
+			if dof, ok := dofs.Lookup(name); ok {
+				diaphragmDofs = append(diaphragmDofs, dof)
+			}
+		}
+
+		master, errMaster := scanForNode(masterID, nodes)
+		slaves := make([]*Node, 0, len(desc.Slaves))
+		errSlaves := errMaster
+
+		for _, id := range desc.Slaves {
+			slave, errSlave := scanForNode(id, nodes)
+			slaves = append(slaves, slave)
+			errSlaves = errors.Join(errSlaves, errSlave)
+		}
+
+		if errSlaves != nil {
+			err = errors.Join(err, fmt.Errorf("construct rigid diaphragm: %w", errSlaves))
+			continue
+		}
+
+		t, diaphragmBCs, errDiaphragm := NewRigidDiaphragm(master, slaves, diaphragmDofs)
+		constraints = append(constraints, t...)
+		bcs = append(bcs, diaphragmBCs...)
+		err = errors.Join(err, errDiaphragm)
+	}
+
+	return constraints, bcs, dofs.FinaliseJoin(err)
+}
+
 type dofLookup struct {
 	dofs    map[string]Dof
 	context string
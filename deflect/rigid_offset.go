@@ -0,0 +1,146 @@
+package deflect
+
+import (
+	"fmt"
+
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/spatial/r3"
+)
+
+// NewOffsetFrame2d returns a 2d frame element with rigid end zones. The offsets are vectors from
+// the first and second node to the respective start and end of the flexible part of the element,
+// given in global coordinates. The flexible part is a regular 2d frame, and all element loads,
+// hinges, and interpolations refer to it, i.e., positions along the element are measured from the
+// start of the flexible part, and interpolations span its length only. The rigid zones couple the
+// nodal degrees of freedom to the flexible ends through the usual small-rotation kinematics of a
+// rigid link.
+func NewOffsetFrame2d(
+	id string,
+	n0, n1 *Node,
+	material *Material,
+	hinges map[Index]struct{},
+	offsets [2]r3.Vec,
+) (Element, error) {
+	// The flexible part gets its own node instances with identical IDs. This way, the symbolic
+	// indices stay the same, while the element geometry is the one of the flexible part.
+	shifted := [...]*Node{
+		{ID: n0.ID, X: n0.X + offsets[0].X, Y: n0.Y + offsets[0].Y, Z: n0.Z + offsets[0].Z},
+		{ID: n1.ID, X: n1.X + offsets[1].X, Y: n1.Y + offsets[1].Y, Z: n1.Z + offsets[1].Z},
+	}
+
+	flexible, err := NewFrame2d(id, shifted[0], shifted[1], material, hinges)
+	if err != nil {
+		return nil, fmt.Errorf("failed to instantiate flexible part of offset frame: %w", err)
+	}
+
+	concrete, ok := flexible.(*frame)
+	if !ok {
+		return nil, fmt.Errorf("bug: can't downcast fresh 2d frame instance")
+	}
+
+	result := &offsetFrame2d{frame: *concrete, offsets: offsets}
+	indices := map[Index]int{}
+
+	for i, index := range result.indicesAsArray() {
+		indices[index] = i
+	}
+
+	result.local = newEqLayoutDirect(indices)
+
+	return result, nil
+}
+
+type offsetFrame2d struct {
+	// The flexible part of the element. It is assembled into an element-sized system of equations
+	// at the flexible ends, which is then transformed to the nodes.
+	frame
+	offsets [2]r3.Vec
+	local   EqLayout
+}
+
+func (f *offsetFrame2d) Assemble(indices EqLayout, k *mat.SymDense, r, d *mat.VecDense) {
+	idx := f.indicesAsArray()
+	global := [len(idx)]int{}
+
+	for i, index := range idx {
+		global[i] = indices.mapOne(index)
+	}
+
+	kl := mat.NewSymDense(len(idx), nil)
+	rl := mat.NewVecDense(len(idx), nil)
+	dl := f.flexibleEndValues(indices, d)
+
+	f.frame.Assemble(f.local, kl, rl, dl)
+
+	// With u_e = t·u_n, where u_e are the values at the flexible ends and u_n the nodal ones, the
+	// element contributes tᵀ·k·t and tᵀ·r to the global tangent and residual.
+	t := f.transformation()
+	var tk, kn mat.Dense
+	tk.Mul(t.T(), kl)
+	kn.Mul(&tk, t)
+	rl.MulVec(t.T(), rl)
+
+	for i := range idx {
+		r.SetVec(global[i], r.AtVec(global[i])+rl.AtVec(i))
+
+		for j := i; j < len(idx); j++ {
+			gi, gj := global[i], global[j]
+			k.SetSym(gi, gj, k.At(gi, gj)+kn.At(i, j))
+		}
+	}
+}
+
+func (f *offsetFrame2d) Interpolate(indices EqLayout, which Fct, d *mat.VecDense) PolySequence {
+	return f.frame.Interpolate(f.local, which, f.flexibleEndValues(indices, d))
+}
+
+// flexibleEndValues returns the primary values at the flexible ends of the element, given the
+// global primary values d. The result can be accessed with f.local.
+func (f *offsetFrame2d) flexibleEndValues(indices EqLayout, d *mat.VecDense) *mat.VecDense {
+	idx := f.indicesAsArray()
+	nodal := mat.NewVecDense(len(idx), nil)
+
+	for i, index := range idx {
+		nodal.SetVec(i, d.AtVec(indices.mapOne(index)))
+	}
+
+	nodal.MulVec(f.transformation(), nodal)
+
+	return nodal
+}
+
+// transformation returns the matrix t that maps the nodal values onto the values at the flexible
+// ends. A rotation φ about the global y-axis moves the end of an offset vector o by
+//
+//	φ·ŷ × o = (φ·oz, 0, -φ·ox),
+//
+// while the rotation itself is identical at both ends of the rigid zone.
+func (f *offsetFrame2d) transformation() *mat.Dense {
+	t := mat.NewDense(6, 6, nil)
+
+	for i, o := range f.offsets {
+		ux, uz, phiy := 3*i, 3*i+1, 3*i+2
+
+		t.Set(ux, ux, 1)
+		t.Set(uz, uz, 1)
+		t.Set(phiy, phiy, 1)
+		t.Set(ux, phiy, o.Z)
+		t.Set(uz, phiy, -o.X)
+	}
+
+	return t
+}
+
+func (f *offsetFrame2d) indicesAsArray() *[6]Index {
+	n0, n1 := f.n0.ID, f.n1.ID
+	indices := [...]Index{
+		{NodalID: n0, Dof: Ux},
+		{NodalID: n0, Dof: Uz},
+		{NodalID: n0, Dof: Phiy},
+		{NodalID: n1, Dof: Ux},
+		{NodalID: n1, Dof: Uz},
+		{NodalID: n1, Dof: Phiy},
+	}
+
+	return &indices
+}
@@ -0,0 +1,264 @@
+package deflect
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"gonum.org/v1/gonum/floats/scalar"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/spatial/r3"
+)
+
+// NewSkewedSupport instantiates a support at the given node whose restraints act in a local frame
+// of arbitrary orientation. The columns of rot are the local x, y, and z-axis in global
+// coordinates. All translational degrees of freedom of the node are rotated into the local frame
+// if any of the restrained ones is translational, and the same holds for the rotational ones. The
+// returned zero Dirichlet BCs refer to the local frame, e.g. restraining Uz prevents displacements
+// along the local z-axis, and any other Dirichlet BC for a rotated index of the node is interpreted
+// in the local frame, too. Results are transformed back to global coordinates, while
+// [SkewedSupport.LocalReactions] retrieves reactions in the local frame.
+//
+// When rot is a rotation about the global y-axis and only Ux, Uz, and Phiy are restrained, the
+// support is planar: only Ux and Uz are rotated, since Phiy is identical in both frames. This is
+// the case for supports in the x-z plane of 2d problems, which lack the other degrees of freedom.
+func NewSkewedSupport(
+	nodeID string,
+	rot *r3.Mat,
+	restrained []Dof,
+) (*SkewedSupport, []NodalValue, error) {
+	var rtr r3.Mat
+	rtr.Mul(rot.T(), rot)
+
+	if !mat.EqualApprox(&rtr, r3.Eye(), 1e-10) || !scalar.EqualWithinAbs(rot.Det(), 1, 1e-10) {
+		return nil, nil, fmt.Errorf("skewed support at %v: rotation matrix must be orthonormal", nodeID)
+	}
+
+	support := &SkewedSupport{rot: r3.NewMat(nil)}
+	support.rot.CloneFrom(rot)
+
+	isTranslation := func(dof Dof) bool { return dof == Ux || dof == Uy || dof == Uz }
+	isRotation := func(dof Dof) bool { return dof == Phix || dof == Phiy || dof == Phiz }
+	isOutOfPlane := func(dof Dof) bool { return dof == Uy || dof == Phix || dof == Phiz }
+	index := func(dof Dof) Index { return Index{NodalID: nodeID, Dof: dof} }
+	planar := scalar.EqualWithinAbs(rot.At(1, 1), 1, 1e-10) &&
+		!slices.ContainsFunc(restrained, isOutOfPlane)
+
+	if planar && slices.ContainsFunc(restrained, isTranslation) {
+		support.groups = append(support.groups, skewedGroup{
+			indices: []Index{index(Ux), index(Uz)}, axes: []int{0, 2},
+		})
+	} else if !planar {
+		if slices.ContainsFunc(restrained, isTranslation) {
+			support.groups = append(support.groups, skewedGroup{
+				indices: []Index{index(Ux), index(Uy), index(Uz)}, axes: []int{0, 1, 2},
+			})
+		}
+
+		if slices.ContainsFunc(restrained, isRotation) {
+			support.groups = append(support.groups, skewedGroup{
+				indices: []Index{index(Phix), index(Phiy), index(Phiz)}, axes: []int{0, 1, 2},
+			})
+		}
+	}
+
+	bcs := make([]NodalValue, len(restrained))
+
+	for i, dof := range restrained {
+		bcs[i] = NodalValue{Index: index(dof), Value: 0}
+	}
+
+	return support, bcs, nil
+}
+
+// SkewedFrameFromDirection returns an orthonormal frame whose local z-axis points along the given
+// direction, e.g. the normal of the plane a support slides on. The local x and y-axis are
+// perpendicular to it, where the local y-axis is perpendicular to the global x-axis (or the global
+// y-axis, if the direction is parallel to the global x-axis).
+func SkewedFrameFromDirection(direction r3.Vec) (*r3.Mat, error) {
+	if r3.Norm(direction) == 0 {
+		return nil, errors.New("can't construct skewed frame from zero direction")
+	}
+
+	z := r3.Unit(direction)
+	helper := r3.Vec{X: 1}
+
+	if math.Abs(r3.Dot(z, helper)) > 1-1e-6 {
+		helper = r3.Vec{Y: 1}
+	}
+
+	y := r3.Unit(r3.Cross(z, helper))
+	x := r3.Cross(y, z)
+
+	return r3.NewMat([]float64{
+		x.X, y.X, z.X,
+		x.Y, y.Y, z.Y,
+		x.Z, y.Z, z.Z,
+	}), nil
+}
+
+// SkewedFrameFromEulerAngles returns the frame that results from rotating the global frame
+// subsequently by yaw about its z-axis, by pitch about the new y-axis, and by roll about the new
+// x-axis (intrinsic Tait-Bryan angles, in radians).
+func SkewedFrameFromEulerAngles(yaw, pitch, roll float64) *r3.Mat {
+	var yawPitch, result r3.Mat
+
+	yawPitch.Mul(r3.NewRotation(yaw, r3.Vec{Z: 1}).Mat(), r3.NewRotation(pitch, r3.Vec{Y: 1}).Mat())
+	result.Mul(&yawPitch, r3.NewRotation(roll, r3.Vec{X: 1}).Mat())
+
+	return &result
+}
+
+// SkewedSupport transforms the translational and/or rotational degrees of freedom of one node into
+// a local frame, see [NewSkewedSupport].
+type SkewedSupport struct {
+	groups []skewedGroup
+	rot    *r3.Mat
+	// Scratch buffers for the transformation of the tangent, one per row of a group.
+	rows [3]*mat.VecDense
+}
+
+// skewedGroup is a set of indices of the same node that are rotated together. The axes are the
+// rows and columns of the rotation matrix that correspond to the indices, i.e., the rotation of a
+// group is a square block of the full rotation matrix.
+type skewedGroup struct {
+	indices []Index
+	axes    []int
+}
+
+// Pre computes tᵀ·k·t and tᵀ·r, where t is the identity except for the rotation blocks of the
+// transformed groups. Prescribed values in d already refer to the local frame and are left as is.
+func (s *SkewedSupport) Pre(indices EqLayout, k *mat.SymDense, r, d *mat.VecDense) {
+	dim := k.SymmetricDim()
+
+	if s.rows[0] == nil || s.rows[0].Len() != dim {
+		for i := range s.rows {
+			s.rows[i] = mat.NewVecDense(dim, nil)
+		}
+	}
+
+	for _, group := range s.groups {
+		idx := s.mapGroup(indices, group)
+
+		// Rows of tᵀ·k for the indices of the group:
+		for a := range idx {
+			for m := range dim {
+				var value float64
+
+				for b, i := range idx {
+					value += s.at(group, b, a) * k.At(i, m)
+				}
+
+				s.rows[a].SetVec(m, value)
+			}
+		}
+
+		// The diagonal block needs another multiplication with the rotation from the right.
+		var block [3][3]float64
+
+		for a := range idx {
+			for c := range idx {
+				for b, i := range idx {
+					block[a][c] += s.rows[a].AtVec(i) * s.at(group, b, c)
+				}
+			}
+		}
+
+		for a, i := range idx {
+			for m := range dim {
+				k.SetSym(i, m, s.rows[a].AtVec(m))
+			}
+		}
+
+		for a, i := range idx {
+			for c, j := range idx {
+				k.SetSym(i, j, block[a][c])
+			}
+		}
+
+		s.rotate(group, idx, r, true)
+	}
+}
+
+// Post computes t·d and t·r, i.e., the results in global coordinates.
+func (s *SkewedSupport) Post(indices EqLayout, r, d *mat.VecDense) {
+	for _, group := range s.groups {
+		idx := s.mapGroup(indices, group)
+
+		s.rotate(group, idx, d, false)
+		s.rotate(group, idx, r, false)
+	}
+}
+
+// LocalReactions returns the reactions of the transformed degrees of freedom in the local frame of
+// the support, given a result that was computed with s as part of the problem. For degrees of
+// freedom that aren't restrained, the values are the nodal loads in the local frame.
+func (s *SkewedSupport) LocalReactions(result ProblemResult) ([]NodalValue, error) {
+	var values []NodalValue
+	var err error
+
+	for _, group := range s.groups {
+		var global [3]float64
+
+		for i, index := range group.indices {
+			reaction, errSingle := result.Reaction(index)
+			global[i] = reaction.Value
+			err = errors.Join(err, errSingle)
+		}
+
+		for a, index := range group.indices {
+			var local float64
+
+			for b := range group.indices {
+				local += s.at(group, b, a) * global[b]
+			}
+
+			values = append(values, NodalValue{Index: index, Value: local})
+		}
+	}
+
+	return values, err
+}
+
+// rotates returns true if the given index is transformed into the local frame.
+func (s *SkewedSupport) rotates(index Index) bool {
+	return slices.ContainsFunc(s.groups, func(group skewedGroup) bool {
+		return slices.Contains(group.indices, index)
+	})
+}
+
+// at returns the entry of the rotation block of the given group.
+func (s *SkewedSupport) at(group skewedGroup, i, j int) float64 {
+	return s.rot.At(group.axes[i], group.axes[j])
+}
+
+func (s *SkewedSupport) mapGroup(indices EqLayout, group skewedGroup) []int {
+	idx := make([]int, len(group.indices))
+
+	for i, index := range group.indices {
+		idx[i] = indices.mapOne(index)
+	}
+
+	return idx
+}
+
+// rotate transforms the entries of v at the given indices into the local frame if toLocal is true,
+// otherwise into the global frame.
+func (s *SkewedSupport) rotate(group skewedGroup, idx []int, v *mat.VecDense, toLocal bool) {
+	var values [3]float64
+
+	for a := range idx {
+		for b, i := range idx {
+			if toLocal {
+				values[a] += s.at(group, b, a) * v.AtVec(i)
+			} else {
+				values[a] += s.at(group, a, b) * v.AtVec(i)
+			}
+		}
+	}
+
+	for a, i := range idx {
+		v.SetVec(i, values[a])
+	}
+}
@@ -0,0 +1,205 @@
+package deflect
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/floats/scalar"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/spatial/r3"
+)
+
+func TestSkewedSupportTransformation(t *testing.T) {
+	// Compares the in-place transformation with the one through a dense transformation matrix, see
+	// also the inclined support test.
+	ux, uy := Index{NodalID: "A", Dof: Ux}, Index{NodalID: "A", Dof: Uy}
+	uz := Index{NodalID: "A", Dof: Uz}
+	indices := EqLayout{indices: map[Index]int{ux: 4, uy: 11, uz: 12}, inverse: nil}
+	dim := 20
+	rot := SkewedFrameFromEulerAngles(0.3, -1.2, 2.1)
+
+	support, bcs, err := NewSkewedSupport("A", rot, []Dof{Uz})
+	if err != nil {
+		t.Fatalf("Expected successful skewed support instantiation, got %v", err)
+	} else if len(bcs) != 1 || bcs[0].Index != uz || bcs[0].Value != 0 {
+		t.Errorf("Expected one zero Dirichlet BC for A/Uz, got %v", bcs)
+	}
+
+	k, r, d := matricesToTransform(dim)
+	kref, rref, dref := referenceMatricesToTransform(k, r, d)
+	trafo := mat.NewDense(dim, dim, nil)
+	idx := []int{4, 11, 12}
+
+	for i := range dim {
+		trafo.Set(i, i, 1)
+	}
+
+	for a, i := range idx {
+		for b, j := range idx {
+			trafo.Set(i, j, rot.At(a, b))
+		}
+	}
+
+	kref.Mul(trafo.T(), kref)
+	kref.Mul(kref, trafo)
+	rref.MulVec(trafo.T(), rref)
+
+	support.Pre(indices, k, r, d)
+
+	if !mat.EqualApprox(k, kref, 1e-8) {
+		t.Errorf("Expected Pre operation to compute tᵀ·k·t, but reference result differs")
+	}
+	if !mat.EqualApprox(r, rref, 1e-8) {
+		t.Errorf("Expected Pre operation to compute tᵀ·r, but reference result differs")
+	}
+
+	support.Post(indices, r, d)
+
+	dref.MulVec(trafo, dref)
+	rref.MulVec(trafo, rref)
+
+	if !mat.EqualApprox(d, dref, 1e-8) {
+		t.Errorf("Expected Post to compute t·d, but reference result differs")
+	}
+	if !mat.EqualApprox(r, rref, 1e-8) {
+		t.Errorf("Expected Post to compute t·r, but reference result differs")
+	}
+}
+
+func TestSkewedFrameFromDirection(t *testing.T) {
+	cases := []r3.Vec{{X: 1, Y: 0, Z: 1}, {X: -2}, {Z: 3}, {X: 1, Y: -2, Z: 0.5}}
+
+	for _, direction := range cases {
+		rot, err := SkewedFrameFromDirection(direction)
+
+		if err != nil {
+			t.Fatalf("Expected frame for direction %v, got error %v", direction, err)
+		}
+
+		var rtr r3.Mat
+		rtr.Mul(rot.T(), rot)
+
+		if !mat.EqualApprox(&rtr, r3.Eye(), 1e-12) || !scalar.EqualWithinAbs(rot.Det(), 1, 1e-12) {
+			t.Errorf("Expected orthonormal frame for direction %v, got %v", direction, rot)
+		}
+		if !scalar.EqualWithinAbs(r3.Cos(rot.VecCol(2), direction), 1, 1e-12) {
+			t.Errorf("Expected local z-axis along %v, got %v", direction, rot.VecCol(2))
+		}
+	}
+
+	if _, err := SkewedFrameFromDirection(r3.Vec{}); err == nil {
+		t.Errorf("Expected error for zero direction")
+	}
+}
+
+func TestSkewedSupportRejectsNonOrthonormal(t *testing.T) {
+	rot := r3.NewMat([]float64{1, 0, 0, 0, 2, 0, 0, 0, 1})
+
+	if _, _, err := NewSkewedSupport("A", rot, []Dof{Ux}); err == nil {
+		t.Errorf("Expected error for non-orthonormal rotation matrix")
+	}
+}
+
+func TestSkewedSupportLocalReactions(t *testing.T) {
+	// Horizontal bar with its end sliding on a plane with normal (1, 0, 1), loaded by a vertical
+	// force P. The support reaction along the normal must be -√2·P.
+	nodes := []Node{{ID: "A"}, {ID: "B", X: 2}}
+	truss, _ := NewTruss3d("AB", &nodes[0], &nodes[1], &exampleMat, map[Index]struct{}{})
+	rot, _ := SkewedFrameFromDirection(r3.Vec{X: 1, Z: 1})
+	support, bcs, _ := NewSkewedSupport("B", rot, []Dof{Uy, Uz})
+	P := 1e3
+
+	p := Problem{
+		Nodes:    nodes,
+		Elements: []Element{truss},
+		Dirichlet: append([]NodalValue{
+			{Index: Index{NodalID: "A", Dof: Ux}},
+			{Index: Index{NodalID: "A", Dof: Uy}},
+			{Index: Index{NodalID: "A", Dof: Uz}},
+		}, bcs...),
+		Neumann:      []NodalValue{{Index: Index{NodalID: "B", Dof: Uz}, Value: P}},
+		EqTransforms: []Transformer{support},
+	}
+
+	indices, err := NewEqLayout(&p)
+	if err != nil {
+		t.Fatalf("Expected valid equation layout, got %v", err)
+	}
+
+	result, err := NewLinearProblemSolver().Solve(&p, indices, NewCholeskySolver())
+	if err != nil {
+		t.Fatalf("Expected successful solution, got %v", err)
+	}
+
+	local, err := support.LocalReactions(result)
+	if err != nil {
+		t.Fatalf("Expected local reactions, got %v", err)
+	}
+
+	// The local x-axis is free, so its value is the load component P·(1, 0, -1)/√2.
+	expected := []float64{-P / math.Sqrt2, 0, -math.Sqrt2 * P}
+
+	if len(local) != 3 {
+		t.Fatalf("Expected local reactions for one triple, got %v", local)
+	}
+
+	for i, value := range expected {
+		if !scalar.EqualWithinAbsOrRel(value, local[i].Value, 1e-8, 1e-8) {
+			t.Errorf("Expected local reaction %v, got %v", value, local[i])
+		}
+	}
+}
+
+func TestSkewedSupportPlanar(t *testing.T) {
+	// Same as above, but as a 2d truss, which lacks Uy. The frame is a rotation about the global
+	// y-axis, so only Ux and Uz are transformed.
+	nodes := []Node{{ID: "A"}, {ID: "B", X: 2}}
+	truss, _ := NewTruss2d("AB", &nodes[0], &nodes[1], &exampleMat, map[Index]struct{}{})
+	rot, _ := SkewedFrameFromDirection(r3.Vec{X: 1, Z: 1})
+	support, bcs, err := NewSkewedSupport("B", rot, []Dof{Uz})
+	P := 1e3
+
+	if err != nil {
+		t.Fatalf("Expected successful skewed support instantiation, got %v", err)
+	}
+
+	p := Problem{
+		Nodes:    nodes,
+		Elements: []Element{truss},
+		Dirichlet: append([]NodalValue{
+			{Index: Index{NodalID: "A", Dof: Ux}},
+			{Index: Index{NodalID: "A", Dof: Uz}},
+		}, bcs...),
+		Neumann:      []NodalValue{{Index: Index{NodalID: "B", Dof: Uz}, Value: P}},
+		EqTransforms: []Transformer{support},
+	}
+
+	result := solveTestProblem(t, &p)
+	ux, uz := Index{NodalID: "B", Dof: Ux}, Index{NodalID: "B", Dof: Uz}
+	expected := map[Index]float64{ux: -P / math.Sqrt2, uz: -math.Sqrt2 * P}
+
+	for index, value := range expected {
+		local, err := result.LocalReaction(index)
+
+		if err != nil {
+			t.Fatalf("Expected local reaction at %v, got %v", index, err)
+		} else if !scalar.EqualWithinAbsOrRel(value, local.Value, 1e-8, 1e-8) {
+			t.Errorf("Expected local reaction %v, got %v", value, local)
+		}
+	}
+
+	// The node slides along the local x-axis (1, 0, -1)/√2.
+	dx, _ := result.Primary(ux)
+	dz, _ := result.Primary(uz)
+
+	if dx.Value >= 0 || !scalar.EqualWithinAbsOrRel(dx.Value, -dz.Value, 1e-12, 1e-8) {
+		t.Errorf("Expected displacement along (1, 0, -1), got %v, %v", dx, dz)
+	}
+
+	global, _ := result.Reaction(Index{NodalID: "A", Dof: Ux})
+	local, err := result.LocalReaction(Index{NodalID: "A", Dof: Ux})
+
+	if err != nil || local != global {
+		t.Errorf("Expected global reaction %v without skewed support, got %v (%v)", global, local, err)
+	}
+}
@@ -0,0 +1,53 @@
+package deflect
+
+import (
+	"context"
+	"sync"
+)
+
+// SolverPool is a [ProblemSolver] that is safe for concurrent use, e.g. by the request handlers of
+// a web service. Each call takes a solver created by [NewLinearProblemSolver] from a pool or
+// creates a new one, so that buffers are reused without being shared. Concurrent calls must not
+// share a Problem, since elements and transformers may use internal scratch buffers, too. The same
+// holds for EquationSolver instances with internal state, while the ones in this package are
+// stateless.
+type SolverPool struct {
+	pool sync.Pool
+}
+
+// NewSolverPool creates a pool of linear solvers, which are all configured with the given options.
+// Since consecutive calls may solve unrelated problems, a factorization kept due to
+// [WithFactorizationReuse] is discarded when a solver returns to the pool.
+func NewSolverPool(options ...SolverOption) *SolverPool {
+	return &SolverPool{
+		pool: sync.Pool{
+			New: func() any { return NewLinearProblemSolver(options...) },
+		},
+	}
+}
+
+// Solve is identical to SolveContext with a background context.
+func (sp *SolverPool) Solve(
+	p *Problem,
+	indices EqLayout,
+	strategy EquationSolver,
+) (ProblemResult, error) {
+	return sp.SolveContext(context.Background(), p, indices, strategy)
+}
+
+// SolveContext solves the problem with a solver from the pool, see [ContextProblemSolver].
+func (sp *SolverPool) SolveContext(
+	ctx context.Context,
+	p *Problem,
+	indices EqLayout,
+	strategy EquationSolver,
+) (ProblemResult, error) {
+	solver := sp.pool.Get().(*linearSolver)
+
+	defer func() {
+		solver.factorization = nil
+		sp.pool.Put(solver)
+	}()
+
+	return solver.SolveContext(ctx, p, indices, strategy)
+}
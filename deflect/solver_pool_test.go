@@ -0,0 +1,200 @@
+package deflect
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"gonum.org/v1/gonum/floats/scalar"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestSolveCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	solvers := []ProblemSolver{
+		NewLinearProblemSolver(),
+		NewLinearProblemSolver(WithParallelAssembly(2)),
+		NewSolverPool(),
+	}
+
+	for _, solver := range solvers {
+		p := cantileverTestProblem(t)
+		indices, _ := NewEqLayout(&p)
+
+		_, err := solver.(ContextProblemSolver).SolveContext(ctx, &p, indices, NewCholeskySolver())
+
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected cancellation error, got %v", err)
+		}
+
+		if _, err := solver.Solve(&p, indices, NewCholeskySolver()); err != nil {
+			t.Errorf("Expected solver to be usable after cancellation, got %v", err)
+		}
+	}
+}
+
+// cancellingElement cancels a context when it is assembled.
+type cancellingElement struct {
+	Element
+	cancel context.CancelFunc
+}
+
+func (c *cancellingElement) Assemble(indices EqLayout, k *mat.SymDense, r, d *mat.VecDense) {
+	c.cancel()
+	c.Element.Assemble(indices, k, r, d)
+}
+
+func TestSolveCancelledDuringAssembly(t *testing.T) {
+	for _, workers := range []int{1, 2} {
+		ctx, cancel := context.WithCancel(context.Background())
+		p := simplySupportedTestProblem(t)
+		p.Elements[1] = &cancellingElement{Element: p.Elements[1], cancel: cancel}
+		indices, _ := NewEqLayout(&p)
+		solver := NewLinearProblemSolver(WithParallelAssembly(workers)).(ContextProblemSolver)
+		_, err := solver.SolveContext(ctx, &p, indices, NewCholeskySolver())
+
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("%v worker(s): expected cancellation error, got %v", workers, err)
+		}
+	}
+}
+
+// contextRecorder is a non-factorising EquationSolver that records the context it receives.
+type contextRecorder struct {
+	received context.Context
+}
+
+func (c *contextRecorder) SolveLinearSystem(a mat.Symmetric, b, x *mat.VecDense) error {
+	return NewCholeskySolver().SolveLinearSystem(a, b, x)
+}
+
+func (c *contextRecorder) SolveLinearSystemContext(
+	ctx context.Context,
+	a mat.Symmetric,
+	b, x *mat.VecDense,
+) error {
+	c.received = ctx
+	return c.SolveLinearSystem(a, b, x)
+}
+
+func TestSolveForwardsContext(t *testing.T) {
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, "value")
+	p := cantileverTestProblem(t)
+	indices, _ := NewEqLayout(&p)
+	strategy := &contextRecorder{}
+
+	solver := NewLinearProblemSolver().(ContextProblemSolver)
+
+	if _, err := solver.SolveContext(ctx, &p, indices, strategy); err != nil {
+		t.Fatalf("Expected successful solution, got %v", err)
+	}
+
+	if strategy.received == nil || strategy.received.Value(key{}) != "value" {
+		t.Errorf("Expected context to be forwarded to the equation solver")
+	}
+}
+
+func TestResultIndependentOfSolverReuse(t *testing.T) {
+	solver := NewLinearProblemSolver()
+	p := cantileverTestProblem(t)
+	indices, _ := NewEqLayout(&p)
+	tip := Index{NodalID: "B", Dof: Uz}
+
+	first, err := solver.Solve(&p, indices, NewCholeskySolver())
+	if err != nil {
+		t.Fatalf("Expected successful solution, got %v", err)
+	}
+
+	before, _ := first.Primary(tip)
+	p.Neumann[0].Value *= -3
+
+	if _, err := solver.Solve(&p, indices, NewCholeskySolver()); err != nil {
+		t.Fatalf("Expected successful solution, got %v", err)
+	}
+
+	if after, _ := first.Primary(tip); after != before {
+		t.Errorf("Expected first result to be unchanged at %v, got %v", before, after)
+	}
+}
+
+func TestSolverPoolConcurrent(t *testing.T) {
+	pool := NewSolverPool(WithParallelAssembly(2))
+	tip := Index{NodalID: "B", Dof: Uz}
+
+	reference := cantileverTestProblem(t)
+	indices, _ := NewEqLayout(&reference)
+	result, err := NewLinearProblemSolver().Solve(&reference, indices, NewCholeskySolver())
+	if err != nil {
+		t.Fatalf("Expected successful reference solution, got %v", err)
+	}
+
+	expected, _ := result.Primary(tip)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 16)
+
+	for i := range errs {
+		p := cantileverTestProblem(t)
+		indices, _ := NewEqLayout(&p)
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			result, err := pool.Solve(&p, indices, NewCholeskySolver())
+			if err != nil {
+				errs[i] = err
+				return
+			}
+
+			if actual, _ := result.Primary(tip); actual != expected {
+				errs[i] = errors.New("unexpected tip displacement")
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		t.Errorf("Expected concurrent solutions to succeed, got %v", err)
+	}
+}
+
+func TestSolverPoolDiscardsFactorization(t *testing.T) {
+	pool := NewSolverPool(WithFactorizationReuse())
+	strategy := NewCholeskySolver()
+	tip := Index{NodalID: "B", Dof: Uz}
+
+	short := cantileverTestProblem(t)
+	long := cantileverTestProblem(t)
+	long.Nodes[1].X = 3
+	beam, err := NewFrame2d("AB", &long.Nodes[0], &long.Nodes[1], &exampleMat, map[Index]struct{}{})
+
+	if err != nil {
+		t.Fatalf("Expected successful frame instantiation, got %v", err)
+	}
+
+	long.Elements = []Element{beam}
+
+	for _, p := range []*Problem{&short, &long} {
+		indices, _ := NewEqLayout(p)
+		expected, errExpected := NewLinearProblemSolver().Solve(p, indices, NewCholeskySolver())
+		actual, errActual := pool.Solve(p, indices, strategy)
+
+		if err := errors.Join(errExpected, errActual); err != nil {
+			t.Fatalf("Expected successful solutions, got %v", err)
+		}
+
+		want, _ := expected.Primary(tip)
+		got, _ := actual.Primary(tip)
+
+		if !scalar.EqualWithinAbs(want.Value, got.Value, 1e-12) {
+			t.Errorf("Expected tip displacement %v of same-size problems, got %v", want, got)
+		}
+	}
+}
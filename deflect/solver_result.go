@@ -13,7 +13,15 @@ type solverResult struct {
 	total, net int
 	d, r       *mat.VecDense
 	indices    EqLayout
+	nodes      []Node
 	elements   []Element
+	neumann    []NodalValue // Nodal loads, for computing their work and equilibrium
+	skewed     []*SkewedSupport
+	warnings   []string
+	// The residual k·d - r and the magnitudes of its terms, see residualAndScale:
+	residual, scale *mat.VecDense
+	// Work of the reactions, see supportWork:
+	supportWork float64
 	// Group solution/reaction with symbolic index:
 	dIndexed, rIndexed []NodalValue
 }
@@ -28,6 +36,21 @@ func (sr *solverResult) Reaction(i Index) (NodalValue, error) {
 	return NodalValue{Index: i, Value: sr.r.AtVec(plain)}, sr.indices.flushFailure()
 }
 
+func (sr *solverResult) LocalReaction(i Index) (NodalValue, error) {
+	for _, support := range sr.skewed {
+		if !support.rotates(i) {
+			continue
+		}
+
+		local, err := support.LocalReactions(sr)
+		idx := slices.IndexFunc(local, func(value NodalValue) bool { return value.Index == i })
+
+		return local[idx], err
+	}
+
+	return sr.Reaction(i)
+}
+
 func (sr *solverResult) PrimaryAll() []NodalValue {
 	sr.dIndexed = sr.indexPaired(sr.dIndexed, sr.d)
 	return sr.dIndexed
@@ -95,6 +118,8 @@ func (sr *solverResult) InterpolateAll(zeroTol float64) []Interpolation {
 		FctMy,
 		FctMz,
 		FctMx,
+		FctEpsx,
+		FctKappay,
 	}
 	result := make([]Interpolation, 0, len(quantities)*len(sr.elements))
 	var err error
@@ -0,0 +1,158 @@
+package deflect
+
+import (
+	"fmt"
+	"math"
+)
+
+// elementMaterial returns the material of the element with the given ID, which must be one of the
+// one-dimensional elements of this package.
+func (sr *solverResult) elementMaterial(elmtID string) (*Material, error) {
+	elmt, err := scanForElement(elmtID, sr.elements)
+	if err != nil {
+		return nil, err
+	}
+
+	withMaterial, ok := elmt.(interface{ sectionMaterial() *Material })
+	if !ok {
+		return nil, fmt.Errorf("element %v has no cross section", elmtID)
+	}
+
+	return withMaterial.sectionMaterial(), nil
+}
+
+// axialAndBending returns the normal force and bending moment interpolations of the given element,
+// where the bending moment is nil for a truss.
+func (sr *solverResult) axialAndBending(
+	elmtID string,
+	zeroTol float64,
+) (material *Material, n, my PolySequence, err error) {
+	if material, err = sr.elementMaterial(elmtID); err != nil {
+		return nil, nil, nil, err
+	}
+
+	nx, errNx := sr.Interpolate(elmtID, FctNx, zeroTol)
+	if errNx != nil {
+		return nil, nil, nil, errNx
+	}
+
+	moment, errMy := sr.Interpolate(elmtID, FctMy, zeroTol)
+	if errMy != nil {
+		return nil, nil, nil, errMy
+	}
+
+	return material, nx.Piecewise, moment.Piecewise, nil
+}
+
+func (sr *solverResult) NormalStress(elmtID string, z, zeroTol float64) (PolySequence, error) {
+	material, n, my, err := sr.axialAndBending(elmtID, zeroTol)
+	if err != nil {
+		return nil, err
+	}
+
+	return normalStress(material, n, my, z)
+}
+
+// normalStress returns N/A + My·z/Iyy, or N/A when there is no bending moment.
+func normalStress(material *Material, n, my PolySequence, z float64) (PolySequence, error) {
+	result := n.Scale(1 / material.Area()).Add(my.Scale(z / material.Iyy()))
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("no normal force or bending moment for stress computation")
+	}
+
+	return result, nil
+}
+
+func (sr *solverResult) FibreStresses(
+	elmtID string,
+	zeroTol float64,
+) (top, bottom PolySequence, err error) {
+	material, n, my, err := sr.axialAndBending(elmtID, zeroTol)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var zTop, zBottom float64
+
+	// Without bending, e.g. for a truss, the stress is uniform and fibres don't matter.
+	if my != nil {
+		section, ok := material.CrossSection.(StressSection)
+		if !ok {
+			return nil, nil, fmt.Errorf("cross section of element %v has no fibre distances", elmtID)
+		}
+
+		if zTop, zBottom, err = section.ExtremeFibres(); err != nil {
+			return nil, nil, fmt.Errorf("element %v: %w", elmtID, err)
+		}
+	}
+
+	if top, err = normalStress(material, n, my, zTop); err != nil {
+		return nil, nil, fmt.Errorf("element %v: %w", elmtID, err)
+	}
+
+	bottom, err = normalStress(material, n, my, zBottom)
+
+	return top, bottom, err
+}
+
+func (sr *solverResult) ShearStress(elmtID string, zeroTol float64) (PolySequence, error) {
+	material, err := sr.elementMaterial(elmtID)
+	if err != nil {
+		return nil, err
+	}
+
+	section, ok := material.CrossSection.(StressSection)
+	if !ok {
+		return nil, fmt.Errorf("cross section of element %v has no shear area", elmtID)
+	}
+
+	area, err := section.ShearAreaZ()
+	if err != nil {
+		return nil, fmt.Errorf("element %v: %w", elmtID, err)
+	}
+
+	vz, err := sr.Interpolate(elmtID, FctVz, zeroTol)
+	if err != nil {
+		return nil, err
+	} else if vz.Piecewise == nil {
+		return nil, fmt.Errorf("element %v has no shear force", elmtID)
+	}
+
+	return vz.Piecewise.Scale(1 / area), nil
+}
+
+func (sr *solverResult) Utilization(elmtID string, zeroTol float64) (Extremum, error) {
+	material, err := sr.elementMaterial(elmtID)
+	if err != nil {
+		return Extremum{}, err
+	} else if material.YieldStrength <= 0 {
+		return Extremum{}, fmt.Errorf("material of element %v has no yield strength", elmtID)
+	}
+
+	top, bottom, err := sr.FibreStresses(elmtID, zeroTol)
+	if err != nil {
+		return Extremum{}, err
+	}
+
+	result := Extremum{Element: elmtID, Quantity: FctUnknown}
+
+	for _, stress := range [...]PolySequence{top, bottom} {
+		interpolation := Interpolation{Element: elmtID, Quantity: FctUnknown, Piecewise: stress}
+		lowest, highest, err := interpolation.Extrema()
+
+		if err != nil {
+			return Extremum{}, err
+		}
+
+		for _, candidate := range [...]Extremum{lowest, highest} {
+			utilization := math.Abs(candidate.Value) / material.YieldStrength
+
+			if utilization > result.Value {
+				result.X, result.Value = candidate.X, utilization
+			}
+		}
+	}
+
+	return result, nil
+}
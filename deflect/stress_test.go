@@ -0,0 +1,129 @@
+package deflect
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/floats/scalar"
+)
+
+func TestFibreAndShearStresses(t *testing.T) {
+	b, h, l, q, n := 0.1, 0.2, 4.0, 1e3, 5e3
+	cs, _ := NewRectangularCrossSection(b, h, 0)
+	material := Material{
+		CrossSection:  cs,
+		LinearElastic: LinearElastic{YoungsModulus: 2.1e11, YieldStrength: 235e6},
+	}
+	nodes := []Node{{ID: "A"}, {ID: "B", X: l}}
+	beam, _ := NewFrame2d("AB", &nodes[0], &nodes[1], &material, map[Index]struct{}{})
+	beam.AddLoad(NewElementConstantLoad(Uz, q))
+	p := Problem{
+		Nodes:    nodes,
+		Elements: []Element{beam},
+		Dirichlet: []NodalValue{
+			{Index: Index{NodalID: "A", Dof: Ux}, Value: 0},
+			{Index: Index{NodalID: "A", Dof: Uz}, Value: 0},
+			{Index: Index{NodalID: "B", Dof: Uz}, Value: 0},
+		},
+		Neumann: []NodalValue{{Index: Index{NodalID: "B", Dof: Ux}, Value: n}},
+	}
+	result := solveTestProblem(t, &p)
+
+	area, modulus := b*h, b*h*h/6
+	axial, bending := n/area, q*l*l/8/modulus
+
+	top, bottom, err := result.FibreStresses("AB", 1e-8)
+	if err != nil {
+		t.Fatalf("Expected successful stress computation, got %v", err)
+	}
+
+	middle, errMiddle := result.NormalStress("AB", 0, 1e-8)
+	if errMiddle != nil {
+		t.Fatalf("Expected successful stress computation, got %v", errMiddle)
+	}
+
+	approxEq := func(a, b float64) bool { return scalar.EqualWithinAbsOrRel(a, b, 1e-6, 1e-10) }
+	cases := []struct {
+		name   string
+		stress PolySequence
+		x      float64
+		expect float64
+	}{
+		{name: "top-support", stress: top, x: 0, expect: axial},
+		{name: "top-mid-span", stress: top, x: l / 2, expect: axial - bending},
+		{name: "bottom-mid-span", stress: bottom, x: l / 2, expect: axial + bending},
+		{name: "centroid-mid-span", stress: middle, x: l / 2, expect: axial},
+	}
+
+	for _, c := range cases {
+		if actual, _ := evalRightOf(c.stress, c.x); !approxEq(actual, c.expect) {
+			t.Errorf("%v: expected stress %v, got %v", c.name, c.expect, actual)
+		}
+	}
+
+	shear, err := result.ShearStress("AB", 1e-8)
+	if err != nil {
+		t.Fatalf("Expected successful shear stress computation, got %v", err)
+	}
+
+	if actual, _ := evalRightOf(shear, 0); !approxEq(actual, 1.5*q*l/2/area) {
+		t.Errorf("Expected support shear stress %v, got %v", 1.5*q*l/2/area, actual)
+	}
+
+	utilization, err := result.Utilization("AB", 1e-8)
+	if err != nil {
+		t.Fatalf("Expected successful utilization, got %v", err)
+	}
+
+	if expect := (axial + bending) / 235e6; !approxEq(utilization.Value, expect) ||
+		!approxEq(utilization.X, l/2) {
+		t.Errorf("Expected utilization %v at mid-span, got %v", expect, utilization)
+	}
+}
+
+func TestStressFailures(t *testing.T) {
+	noStress, _ := NewConstantsCrossSections(map[string]float64{"A": 1, "Iyy": 1, "Izz": 1})
+	material := Material{CrossSection: noStress, LinearElastic: LinearElastic{YoungsModulus: 1e9}}
+	nodes := []Node{{ID: "A"}, {ID: "B", X: 2}, {ID: "C", X: 2, Z: 1}}
+	hinges := map[Index]struct{}{}
+	beam, _ := NewFrame2d("AB", &nodes[0], &nodes[1], &material, hinges)
+	truss, _ := NewTruss2d("BC", &nodes[1], &nodes[2], &material, hinges)
+	p := Problem{
+		Nodes:    nodes,
+		Elements: []Element{beam, truss},
+		Dirichlet: []NodalValue{
+			{Index: Index{NodalID: "A", Dof: Ux}, Value: 0},
+			{Index: Index{NodalID: "A", Dof: Uz}, Value: 0},
+			{Index: Index{NodalID: "A", Dof: Phiy}, Value: 0},
+			{Index: Index{NodalID: "C", Dof: Ux}, Value: 0},
+		},
+		Neumann: []NodalValue{{Index: Index{NodalID: "B", Dof: Uz}, Value: 1e3}},
+	}
+	result := solveTestProblem(t, &p)
+
+	if _, _, err := result.FibreStresses("AB", 1e-8); err == nil {
+		t.Errorf("Expected fibre stresses to fail without fibre distances")
+	}
+
+	if _, err := result.ShearStress("AB", 1e-8); err == nil {
+		t.Errorf("Expected shear stress to fail without shear area")
+	}
+
+	if _, err := result.Utilization("AB", 1e-8); err == nil {
+		t.Errorf("Expected utilization to fail without yield strength")
+	}
+
+	if _, err := result.NormalStress("XY", 0, 1e-8); err == nil {
+		t.Errorf("Expected stress of unknown element to fail")
+	}
+
+	// A truss has uniform stresses and needs no fibre distances.
+	top, bottom, err := result.FibreStresses("BC", 1e-8)
+	force, _ := result.Interpolate("BC", FctNx, 1e-8)
+	expect, _ := evalRightOf(force.Piecewise, 0)
+	actualTop, _ := evalRightOf(top, 0.5)
+	actualBottom, _ := evalRightOf(bottom, 0.5)
+
+	if err != nil || !scalar.EqualWithinAbs(actualTop, expect, 1e-8) || actualTop != actualBottom {
+		t.Errorf("Expected uniform stress %v, got %v/%v (%v)", expect, actualTop, actualBottom, err)
+	}
+}
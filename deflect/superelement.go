@@ -0,0 +1,336 @@
+package deflect
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// NewSuperelement condenses the given elements to the degrees of freedom of the boundary nodes.
+// The result is an [Element] with a stored condensed tangent and load vector, while all other
+// degrees of freedom are internal and eliminated by static condensation. Element loads must be
+// added to the elements before condensation, and internal nodes can't have nodal BCs. The
+// internal degrees of freedom must be restrained by the elements alone, given fixed boundary
+// nodes. Use [Superelement.Recover] to retrieve internal results after solving a problem that
+// contains the superelement, and [Superelement.Instance] to reuse the condensation for identical
+// substructures.
+func NewSuperelement(id string, elements []Element, boundary []string) (*Superelement, error) {
+	if id == "" {
+		return nil, errors.New("superelement IDs can't be empty")
+	} else if len(elements) == 0 {
+		return nil, fmt.Errorf("superelement %v: no elements to condense", id)
+	}
+
+	all := map[Index]struct{}{}
+	outer := map[Index]struct{}{}
+
+	for _, e := range elements {
+		e.Indices(all)
+	}
+
+	for index := range all {
+		if slices.Contains(boundary, index.NodalID) {
+			outer[index] = struct{}{}
+		}
+	}
+
+	if nb, n := len(outer), len(all); nb == 0 || nb == n {
+		return nil, fmt.Errorf(
+			"superelement %v: needs boundary and internal dofs, got %v and %v", id, nb, n-nb)
+	}
+
+	c, err := condense(elements, all, outer)
+	if err != nil {
+		return nil, fmt.Errorf("superelement %v: %w", id, err)
+	}
+
+	return &Superelement{id: id, condensed: c}, nil
+}
+
+// Superelement is a condensed group of elements, see [NewSuperelement].
+type Superelement struct {
+	id        string
+	condensed *condensation
+	// Maps node IDs of the original boundary nodes to the ones of this instance. Nil when this is
+	// not an instance.
+	rename map[string]string
+}
+
+// condensation stores the result of the static condensation, which is shared by all instances of
+// a superelement. The equations are ordered such that boundary indices come first.
+type condensation struct {
+	elements     []Element
+	local        EqLayout
+	outer, inner []Index
+	// Condensed tangent and load vector for the boundary indices.
+	k *mat.SymDense
+	r *mat.VecDense
+	// The internal primary values are recovered as y - x·d_b with the boundary values d_b.
+	x *mat.Dense
+	y *mat.VecDense
+}
+
+// condense assembles the elements in a local system of equations and eliminates the indices that
+// aren't part of outer.
+func condense(elements []Element, all, outer map[Index]struct{}) (*condensation, error) {
+	// Partition the local system just like Dirichlet BCs do for the global one:
+	local := newEqLayoutDirect(freeAndConstraintsToIndices(all, outer))
+	n, nb := len(all), len(outer)
+	ni := n - nb
+	k := mat.NewSymDense(n, nil)
+	r := mat.NewVecDense(n, nil)
+	d := mat.NewVecDense(n, nil)
+
+	for _, e := range elements {
+		e.Assemble(local, k, r, d)
+	}
+
+	if err := local.flushFailure(); err != nil {
+		return nil, fmt.Errorf("failed to assemble elements: %w", err)
+	}
+
+	// With the partitioning
+	//   ⎡k_bb k_bi⎤⎡d_b⎤  ⎡r_b⎤
+	//   ⎣k_ib k_ii⎦⎣d_i⎦  ⎣r_i⎦
+	// we have d_i = y - x·d_b with x = k_ii⁻¹·k_ib and y = k_ii⁻¹·r_i, and the condensed system is
+	//   [k_bb - k_bi·x][d_b] = [r_b - k_bi·y].
+	kii := k.SliceSym(nb, n)
+	kbi := mat.NewDense(nb, ni, nil)
+
+	for i := range nb {
+		for j := range ni {
+			kbi.Set(i, j, k.At(i, nb+j))
+		}
+	}
+
+	factorization, err := NewCholeskySolver().(Factorizer).Factorize(kii)
+	if err != nil {
+		return nil, fmt.Errorf("internal dofs aren't restrained by the elements: %w", err)
+	}
+
+	x := mat.NewDense(ni, nb, nil)
+	y := mat.NewVecDense(ni, nil)
+	column := mat.NewVecDense(ni, nil)
+
+	for j := range nb {
+		if err := factorization.Solve(mat.VecDenseCopyOf(kbi.RowView(j)), column); err != nil {
+			return nil, err
+		}
+
+		x.SetCol(j, column.RawVector().Data)
+	}
+
+	if err := factorization.Solve(r.SliceVec(nb, n).(*mat.VecDense), y); err != nil {
+		return nil, err
+	}
+
+	var kbix mat.Dense
+	kbix.Mul(kbi, x)
+
+	kc := mat.NewSymDense(nb, nil)
+	rc := mat.NewVecDense(nb, nil)
+	rc.MulVec(kbi, y)
+	rc.SubVec(r.SliceVec(0, nb), rc)
+
+	for i := range nb {
+		for j := i; j < nb; j++ {
+			// Symmetric in exact arithmetic, average out the round-off:
+			kc.SetSym(i, j, k.At(i, j)-(kbix.At(i, j)+kbix.At(j, i))/2)
+		}
+	}
+
+	return &condensation{
+		elements: elements,
+		local:    local,
+		outer:    local.inverse[:nb],
+		inner:    local.inverse[nb:],
+		k:        kc,
+		r:        rc,
+		x:        x,
+		y:        y,
+	}, nil
+}
+
+// Instance returns a superelement that shares the condensation with s, but connects to other
+// nodes. Every boundary node ID of s must be mapped to the node ID of the instance. This is only
+// valid for a substructure that is identical to the original one up to a translation.
+func (s *Superelement) Instance(id string, nodes map[string]string) (*Superelement, error) {
+	var missing []string
+
+	for _, index := range s.condensed.outer {
+		if _, ok := nodes[index.NodalID]; !ok && !slices.Contains(missing, index.NodalID) {
+			missing = append(missing, index.NodalID)
+		}
+	}
+
+	if id == "" {
+		return nil, errors.New("superelement IDs can't be empty")
+	} else if len(missing) > 0 {
+		return nil, fmt.Errorf("superelement %v: boundary nodes %v not mapped", id, missing)
+	}
+
+	return &Superelement{id: id, condensed: s.condensed, rename: maps.Clone(nodes)}, nil
+}
+
+// Recover returns the result of the condensed substructure, given the result of a problem that s
+// is part of. The recovered result refers to the node IDs of the original substructure, i.e., not
+// to the renamed ones of an instance, and interpolates its elements. Nodal loads at the boundary
+// nodes are loads of the recovered result, too. Reactions are the remaining forces k·d - r of the
+// substructure, i.e., the interface forces at the boundary and zero for internal indices.
+func (s *Superelement) Recover(result ProblemResult) (ProblemResult, error) {
+	c := s.condensed
+	nb, ni := len(c.outer), len(c.inner)
+	n := nb + ni
+	d := mat.NewVecDense(n, nil)
+	var err error
+	// The layout collects lookup failures, so the recovered result needs its own one, since the
+	// condensation is shared with all instances that might be recovered concurrently.
+	local := EqLayout{indices: c.local.indices, inverse: c.local.inverse}
+
+	for i, index := range c.outer {
+		value, errSingle := result.Primary(s.instanceIndex(index))
+		d.SetVec(i, value.Value)
+		err = errors.Join(err, errSingle)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("superelement %v: boundary values not found: %w", s.id, err)
+	}
+
+	db := d.SliceVec(0, nb)
+	di := d.SliceVec(nb, n).(*mat.VecDense)
+	di.MulVec(c.x, db)
+	di.SubVec(c.y, di)
+
+	k := mat.NewSymDense(n, nil)
+	r := mat.NewVecDense(n, nil)
+
+	for _, e := range c.elements {
+		e.Assemble(local, k, r, d)
+	}
+
+	reactions, scale := residualAndScale(k, d, r)
+	var neumann []NodalValue
+
+	if outer, ok := result.(*solverResult); ok {
+		for i, index := range c.outer {
+			for _, load := range outer.neumann {
+				if load.Index == s.instanceIndex(index) {
+					neumann = append(neumann, NodalValue{Index: index, Value: load.Value})
+					reactions.SetVec(i, reactions.AtVec(i)-load.Value)
+				}
+			}
+		}
+	}
+
+	return &solverResult{
+		total:       n,
+		net:         ni,
+		d:           d,
+		r:           reactions,
+		residual:    reactions,
+		scale:       scale,
+		supportWork: supportWork(reactions, d, scale, n-ni),
+		indices:     local,
+		nodes:       condensedNodes(c.elements),
+		elements:    c.elements,
+		neumann:     neumann,
+	}, local.flushFailure()
+}
+
+// condensedNodes returns the nodes of the given elements, without the ones that are only known to
+// nested superelement instances.
+func condensedNodes(elements []Element) []Node {
+	var result []Node
+	seen := map[string]struct{}{}
+
+	add := func(n *Node) {
+		if _, ok := seen[n.ID]; !ok {
+			seen[n.ID] = struct{}{}
+			result = append(result, *n)
+		}
+	}
+
+	for _, e := range elements {
+		switch elmt := e.(type) {
+		case lineGeometry:
+			n0, n1 := elmt.endNodes()
+			add(n0)
+			add(n1)
+		case *Superelement:
+			if elmt.rename == nil {
+				for _, n := range condensedNodes(elmt.condensed.elements) {
+					add(&n)
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+func (s *Superelement) instanceIndex(index Index) Index {
+	if s.rename != nil {
+		index.NodalID = s.rename[index.NodalID]
+	}
+
+	return index
+}
+
+// Assemble adds the condensed tangent and load vector to k and r.
+func (s *Superelement) Assemble(indices EqLayout, k *mat.SymDense, r, d *mat.VecDense) {
+	c := s.condensed
+	global := make([]int, len(c.outer))
+
+	for i, index := range c.outer {
+		global[i] = indices.mapOne(s.instanceIndex(index))
+	}
+
+	for i, gi := range global {
+		r.SetVec(gi, r.AtVec(gi)+c.r.AtVec(i))
+
+		for j := i; j < len(global); j++ {
+			gj := global[j]
+			k.SetSym(gi, gj, k.At(gi, gj)+c.k.At(i, j))
+		}
+	}
+}
+
+// AddLoad doesn't accept any loads, since they must be added to the elements before condensation.
+func (s *Superelement) AddLoad(NeumannElementBC) bool {
+	return false
+}
+
+// RemoveLoad is a no-op, see [Superelement.AddLoad].
+func (s *Superelement) RemoveLoad(NeumannElementBC) {}
+
+// Interpolate returns nil, use [Superelement.Recover] to interpolate the condensed elements.
+func (s *Superelement) Interpolate(EqLayout, Fct, *mat.VecDense) PolySequence {
+	return nil
+}
+
+// Indices adds the indices of the boundary nodes to set.
+func (s *Superelement) Indices(set map[Index]struct{}) {
+	for _, index := range s.condensed.outer {
+		set[s.instanceIndex(index)] = struct{}{}
+	}
+}
+
+// NumNodes returns the number of boundary nodes.
+func (s *Superelement) NumNodes() uint {
+	nodes := map[string]struct{}{}
+
+	for _, index := range s.condensed.outer {
+		nodes[index.NodalID] = struct{}{}
+	}
+
+	return uint(len(nodes))
+}
+
+// ID returns the ID of the superelement.
+func (s *Superelement) ID() string {
+	return s.id
+}
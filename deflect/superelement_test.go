@@ -0,0 +1,261 @@
+package deflect
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"gonum.org/v1/gonum/floats/scalar"
+)
+
+// continuousBeamFrames returns frames between consecutive nodes, each with a constant load.
+func continuousBeamFrames(t *testing.T, nodes []Node) []Element {
+	t.Helper()
+
+	var result []Element
+
+	for i := range len(nodes) - 1 {
+		id := fmt.Sprintf("%v%v", nodes[i].ID, nodes[i+1].ID)
+		frame, err := NewFrame2d(id, &nodes[i], &nodes[i+1], &exampleMat, map[Index]struct{}{})
+
+		if err != nil {
+			t.Fatalf("Expected successful frame instantiation, got %v", err)
+		}
+
+		frame.AddLoad(NewElementConstantLoad(Uz, 1e3))
+		frame.AddLoad(NewElementConstantLoad(Ux, 2e2))
+		result = append(result, frame)
+	}
+
+	return result
+}
+
+func superelementTestSupports(last string) []NodalValue {
+	return []NodalValue{
+		{Index: Index{NodalID: "N0", Dof: Ux}, Value: 0},
+		{Index: Index{NodalID: "N0", Dof: Uz}, Value: 0},
+		{Index: Index{NodalID: "N0", Dof: Phiy}, Value: 0},
+		{Index: Index{NodalID: last, Dof: Uz}, Value: 0},
+	}
+}
+
+func solveTestProblem(t *testing.T, p *Problem) ProblemResult {
+	t.Helper()
+
+	indices, err := NewEqLayout(p)
+	if err != nil {
+		t.Fatalf("Expected valid equation layout, got %v", err)
+	}
+
+	result, err := NewLinearProblemSolver().Solve(p, indices, NewCholeskySolver())
+	if err != nil {
+		t.Fatalf("Expected successful solution, got %v", err)
+	}
+
+	return result
+}
+
+func TestSuperelementAgreesWithFullModel(t *testing.T) {
+	nodes := []Node{
+		{ID: "N0"},
+		{ID: "N1", X: 1, Z: 0.2},
+		{ID: "N2", X: 2},
+		{ID: "N3", X: 3, Z: 0.2},
+		{ID: "N4", X: 4},
+	}
+	full := Problem{
+		Nodes:     nodes,
+		Elements:  continuousBeamFrames(t, nodes),
+		Dirichlet: superelementTestSupports("N4"),
+		Neumann:   []NodalValue{{Index: Index{NodalID: "N2", Dof: Uz}, Value: 5e2}},
+	}
+	expected := solveTestProblem(t, &full)
+
+	// The template of the two identical modules N0-N1-N2 and N2-N3-N4, with its own nodes.
+	module := []Node{{ID: "T0"}, {ID: "T1", X: 1, Z: 0.2}, {ID: "T2", X: 2}}
+	template, err := NewSuperelement("S0", continuousBeamFrames(t, module), []string{"T0", "T2"})
+	if err != nil {
+		t.Fatalf("Expected successful condensation, got %v", err)
+	}
+
+	first, errFirst := template.Instance("S1", map[string]string{"T0": "N0", "T2": "N2"})
+	second, errSecond := template.Instance("S2", map[string]string{"T0": "N2", "T2": "N4"})
+
+	if errFirst != nil || errSecond != nil {
+		t.Fatalf("Expected successful instantiation, got %v, %v", errFirst, errSecond)
+	}
+
+	condensed := Problem{
+		Nodes:     []Node{nodes[0], nodes[2], nodes[4]},
+		Elements:  []Element{first, second},
+		Dirichlet: superelementTestSupports("N4"),
+		Neumann:   full.Neumann,
+	}
+	actual := solveTestProblem(t, &condensed)
+
+	// The load resultant of instances is unknown, so they are skipped by the equilibrium check.
+	if warnings := actual.Warnings(); len(warnings) != 0 {
+		t.Errorf("Expected no warnings with superelement instances, got %v", warnings)
+	}
+
+	approxEq := func(a, b float64) bool { return scalar.EqualWithinAbsOrRel(a, b, 1e-10, 1e-10) }
+
+	for _, id := range []string{"N0", "N2", "N4"} {
+		for _, dof := range []Dof{Ux, Uz, Phiy} {
+			want, _ := expected.Primary(Index{NodalID: id, Dof: dof})
+			got, err := actual.Primary(Index{NodalID: id, Dof: dof})
+
+			if err != nil || !approxEq(want.Value, got.Value) {
+				t.Errorf("Expected %v, got %v (%v)", want, got, err)
+			}
+		}
+	}
+
+	recovered, err := second.Recover(actual)
+	if err != nil {
+		t.Fatalf("Expected successful recovery, got %v", err)
+	}
+
+	for _, dof := range []Dof{Ux, Uz, Phiy} {
+		want, _ := expected.Primary(Index{NodalID: "N3", Dof: dof})
+		got, err := recovered.Primary(Index{NodalID: "T1", Dof: dof})
+
+		if err != nil || !approxEq(want.Value, got.Value) {
+			t.Errorf("Expected recovered %v, got %v (%v)", want, got, err)
+		}
+	}
+
+	for _, which := range []Fct{FctMy, FctVz, FctNx, FctUz} {
+		want, _ := expected.Interpolate("N3N4", which, 0)
+		got, err := recovered.Interpolate("T1T2", which, 0)
+
+		if err != nil {
+			t.Fatalf("Expected successful interpolation, got %v", err)
+		}
+
+		for _, x := range []float64{0, 0.3, 0.9} {
+			w, _ := evalRightOf(want.Piecewise, x)
+			g, _ := evalRightOf(got.Piecewise, x)
+
+			if !scalar.EqualWithinAbs(w, g, 1e-8) {
+				t.Errorf("Expected %v at %v to be %v, got %v", which, x, w, g)
+			}
+		}
+	}
+
+	// The interface force at the support equals the support reaction, internal ones vanish:
+	support, _ := expected.Reaction(Index{NodalID: "N4", Dof: Uz})
+	boundary, _ := recovered.Reaction(Index{NodalID: "T2", Dof: Uz})
+	internal, _ := recovered.Reaction(Index{NodalID: "T1", Dof: Uz})
+
+	if !approxEq(support.Value, boundary.Value) || !scalar.EqualWithinAbs(internal.Value, 0, 1e-8) {
+		t.Errorf("Expected interface force %v and zero, got %v and %v", support, boundary, internal)
+	}
+
+	// The nodal load at N2 is part of the recovered substructure, which is in equilibrium.
+	residual, err := recovered.Equilibrium()
+
+	if err != nil {
+		t.Fatalf("Expected equilibrium of the recovered substructure, got %v", err)
+	}
+
+	if force, moment := residual.Relative(); force > 1e-10 || moment > 1e-10 {
+		t.Errorf("Expected vanishing residual of the recovered substructure, got %+v", residual)
+	}
+}
+
+func TestSuperelementConstructionFails(t *testing.T) {
+	nodes := []Node{{ID: "N0"}, {ID: "N1", X: 1}, {ID: "N2", X: 2}}
+	frames := continuousBeamFrames(t, nodes)
+	template, _ := NewSuperelement("S", frames, []string{"N0", "N2"})
+
+	cases := []struct {
+		name string
+		fn   func() error
+	}{
+		{"empty-id", func() error {
+			_, err := NewSuperelement("", frames, []string{"N0"})
+			return err
+		}},
+		{"no-elements", func() error {
+			_, err := NewSuperelement("S", nil, []string{"N0"})
+			return err
+		}},
+		{"no-boundary", func() error {
+			_, err := NewSuperelement("S", frames, nil)
+			return err
+		}},
+		{"no-internal", func() error {
+			_, err := NewSuperelement("S", frames, []string{"N0", "N1", "N2"})
+			return err
+		}},
+		{"unrestrained", func() error {
+			// A transverse displacement of the truss end is a mechanism.
+			truss, _ := NewTruss2d("T", &nodes[0], &nodes[1], &exampleMat, map[Index]struct{}{})
+			_, err := NewSuperelement("S", []Element{truss}, []string{"N0"})
+			return err
+		}},
+		{"unmapped", func() error {
+			_, err := template.Instance("S1", map[string]string{"N0": "X"})
+			return err
+		}},
+	}
+
+	for _, c := range cases {
+		if err := c.fn(); err == nil {
+			t.Errorf("%v: expected error, got nil", c.name)
+		}
+	}
+
+	if template.NumNodes() != 2 || template.AddLoad(NewElementConstantLoad(Uz, 1)) {
+		t.Errorf("Expected two boundary nodes and no accepted loads")
+	}
+}
+
+func TestSuperelementRecoverConcurrently(t *testing.T) {
+	nodes := []Node{{ID: "N0"}, {ID: "N2", X: 2}, {ID: "N4", X: 4}}
+	module := []Node{{ID: "T0"}, {ID: "T1", X: 1, Z: 0.2}, {ID: "T2", X: 2}}
+	template, err := NewSuperelement("S0", continuousBeamFrames(t, module), []string{"T0", "T2"})
+	if err != nil {
+		t.Fatalf("Expected successful condensation, got %v", err)
+	}
+
+	first, errFirst := template.Instance("S1", map[string]string{"T0": "N0", "T2": "N2"})
+	second, errSecond := template.Instance("S2", map[string]string{"T0": "N2", "T2": "N4"})
+
+	if errFirst != nil || errSecond != nil {
+		t.Fatalf("Expected successful instantiation, got %v, %v", errFirst, errSecond)
+	}
+
+	p := Problem{
+		Nodes:     nodes,
+		Elements:  []Element{first, second},
+		Dirichlet: superelementTestSupports("N4"),
+	}
+
+	// Both instances share the condensation, which must not be modified by the recovery. Every
+	// recovery reads from its own result, since these aren't meant for concurrent use.
+	instances := []*Superelement{first, second, first, second}
+	errs := make(chan error, len(instances))
+	var wg sync.WaitGroup
+
+	for _, s := range instances {
+		result := solveTestProblem(t, &p)
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			_, err := s.Recover(result)
+			errs <- err
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("Expected successful recovery, got %v", err)
+		}
+	}
+}
@@ -83,6 +83,9 @@ func (t *truss2d) localNoHingeTangent(l float64) *mat.SymDense {
 
 func (t *truss2d) localNoHingeLoads(l float64) *mat.VecDense {
 	var rx0, rx1 float64
+	EA := t.material.YoungsModulus * t.material.Area()
+	// Linear shape functions 1 - x/l and x/l for distributed loads:
+	shapes := [][]float64{{1, -1 / l}, {0, 1 / l}}
 
 	for _, bc := range t.loads {
 		loadDispatch(bc,
@@ -97,9 +100,20 @@ func (t *truss2d) localNoHingeLoads(l float64) *mat.VecDense {
 				rx1 += q * l / 2
 			},
 			func(load *neumannLinear) {
-				q0, q1 := load.first, load.last
-				rx0 += l * (2*q0 + q1) / 6.0
-				rx1 += l * (q0 + 2*q1) / 6.0
+				r := consistentLoads(load.piece(l), shapes...)
+				rx0 += r[0]
+				rx1 += r[1]
+			},
+			func(load *neumannPolynomial) {
+				r := consistentLoads(load.piece(l), shapes...)
+				rx0 += r[0]
+				rx1 += r[1]
+			},
+			func(load *neumannInitialStrain) {
+				// Equivalent to the end forces N = -EA·ε₀ in a fully restrained member:
+				eps0 := load.initialStrain(l, EA)
+				rx0 -= EA * eps0
+				rx1 += EA * eps0
 			})
 	}
 
@@ -122,12 +136,26 @@ func (t *truss2d) indicesAsArray() *[4]Index {
 }
 
 func (t *truss2d) AddLoad(bc NeumannElementBC) bool {
+	if global, ok := bc.(*neumannGlobal); ok {
+		axial, transverse := global.local(sineCosine2d(t.n0, t.n1))
+
+		if transverse != nil || axial != nil && !t.AddLoad(axial) {
+			return false
+		} else if axial != nil {
+			t.addGlobalPart(global, axial)
+		}
+
+		return true
+	}
+
 	var kind Dof
 
 	loadDispatch(bc,
 		func(load *neumannConcentrated) { kind = load.kind },
 		func(load *neumannConstant) { kind = load.kind },
 		func(load *neumannLinear) { kind = load.kind },
+		func(load *neumannPolynomial) { kind = load.kind },
+		func(load *neumannInitialStrain) { kind = Ux },
 	)
 
 	if kind == Ux {
@@ -139,16 +167,17 @@ func (t *truss2d) AddLoad(bc NeumannElementBC) bool {
 
 func (t *truss2d) Interpolate(indices EqLayout, which Fct, d *mat.VecDense) PolySequence {
 	switch which {
-	case FctUx, FctNx:
+	case FctUx, FctNx, FctEpsx:
 	default:
 		return nil
 	}
 
 	ux0, nx0 := t.startNodeValues(indices, d)
-	return t.InterpolateNxOrUx(which, ux0, nx0)
+	return t.InterpolateAxial(which, ux0, nx0)
 }
 
-func (t *truss2d) InterpolateNxOrUx(which Fct, ux0, nx0 float64) PolySequence {
+// InterpolateAxial returns the interpolation of Nx, Ux, or Epsx, given the start node values.
+func (t *truss2d) InterpolateAxial(which Fct, ux0, nx0 float64) PolySequence {
 	nx := t.InterpolateNx(nx0)
 
 	if which == FctNx {
@@ -162,11 +191,39 @@ func (t *truss2d) InterpolateNxOrUx(which Fct, ux0, nx0 float64) PolySequence {
 
 	eps := nx // Mostly a shallow copy, treat as a rename
 	eps.multiply(1 / EA)
-	ux := eps.integrate(ux0)
+	eps = t.addInitialStrain(eps, EA)
+
+	if which == FctEpsx {
+		return eps
+	}
+
+	ux := eps.Integrate(ux0)
 
 	return ux
 }
 
+// addInitialStrain adds imposed strains to the elastic strain eps = N/EA, such that the result is
+// the total strain u'.
+func (t *truss2d) addInitialStrain(eps PolySequence, EA float64) PolySequence {
+	l := length(t.n0, t.n1)
+	var eps0 float64
+
+	for _, bc := range t.loads {
+		loadDispatch(bc,
+			func(*neumannConcentrated) {},
+			func(*neumannConstant) {},
+			func(*neumannLinear) {},
+			func(*neumannPolynomial) {},
+			func(load *neumannInitialStrain) { eps0 += load.initialStrain(l, EA) })
+	}
+
+	if eps0 == 0 {
+		return eps
+	}
+
+	return append(eps, PolyPiece{X0: 0, XE: l, Coeff: []float64{eps0}}).flatten()
+}
+
 func (t *truss2d) InterpolateNx(nx0 float64) PolySequence {
 	l := length(t.n0, t.n1)
 	result := PolySequence{}
@@ -185,11 +242,15 @@ func (t *truss2d) InterpolateNx(nx0 float64) PolySequence {
 				result = append(result, PolyPiece{X0: 0, XE: l, Coeff: []float64{0, -qx}})
 			},
 			func(load *neumannLinear) {
-				q0, qE := load.first, load.last
-				result = append(
-					result,
-					PolyPiece{X0: 0, XE: l, Coeff: []float64{0, -q0, -(qE - q0) / (2 * l)}},
-				)
+				nx, _ := loadIntegrals(load.piece(l), l)
+				result = append(result, nx...)
+			},
+			func(load *neumannPolynomial) {
+				nx, _ := loadIntegrals(load.piece(l), l)
+				result = append(result, nx...)
+			},
+			func(*neumannInitialStrain) {
+				// The normal force is constant, and the imposed strain is part of nx0 already.
 			})
 	}
 
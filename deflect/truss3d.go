@@ -104,15 +104,24 @@ func (t *truss3d) indicesAsArray() *[6]Index {
 	return &indices
 }
 
+func (t *truss3d) AddLoad(bc NeumannElementBC) bool {
+	if _, ok := bc.(*neumannGlobal); ok {
+		// The decomposition of global loads is implemented for 2d elements only.
+		return false
+	}
+
+	return t.truss2d.AddLoad(bc)
+}
+
 func (t *truss3d) Interpolate(indices EqLayout, which Fct, d *mat.VecDense) PolySequence {
 	switch which {
-	case FctUx, FctNx:
+	case FctUx, FctNx, FctEpsx:
 	default:
 		return nil
 	}
 
 	ux0, nx0 := t.startNodeValues(indices, d)
-	return t.InterpolateNxOrUx(which, ux0, nx0)
+	return t.InterpolateAxial(which, ux0, nx0)
 }
 
 func (t *truss3d) startNodeValues(indices EqLayout, d *mat.VecDense) (dx0, nx0 float64) {
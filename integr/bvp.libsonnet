@@ -1,6 +1,7 @@
 {
   pi:: std.acos(0) * 2,
 
+  // Dirichlet BCs. In the neumann section, these declare settlements of existing supports.
   Ux(value=0):: [{ Ux: value }],
   Uy(value=0):: [{ Uy: value }],
   Uz(value=0):: [{ Uz: value }],
@@ -12,6 +13,17 @@
   InclinedSupportUxUy(angle):: [{ from: 'Ux', to: 'Uy', angle: angle }],
   InclinedSupportUyUz(angle):: [{ from: 'Uy', to: 'Uz', angle: angle }],
 
+  // Skewed supports, restrained dofs refer to a local frame given by its z-axis or by Euler angles.
+  SkewedSupport(restrained, direction):: [{ restrained: restrained, direction: direction }],
+  SkewedSupportEuler(restrained, yaw, pitch, roll)::
+    [{ restrained: restrained, euler: [yaw, pitch, roll] }],
+
+  // Linear multi-point constraint for the slave node it's keyed by: u_slave = Σ terms + constant.
+  Constraint(dof, terms, constant=0):: [{ dof: dof, terms: terms, constant: constant }],
+  Term(node, dof, coefficient=1):: { node: node, dof: dof, coefficient: coefficient },
+  RigidLink(master, dofs):: [{ master: master, dofs: dofs }],
+  RigidDiaphragm(slaves, dofs):: { slaves: slaves, dofs: dofs },
+
   local single(what, value, x) =
     if x == null then
       {
@@ -32,37 +44,61 @@
   My(value, x=null):: [single('My', value, x)],
   Mz(value, x=null):: [single('Mz', value, x)],
 
-  local constant(what, value) =
+  local constant(what, value, x=null, frame=null) =
     assert std.isNumber(value);
     {
       kind: what,
       degree: 'constant',
       values: [value],
+      [if x != null then 'position']: x,
+      [if frame != null then 'frame']: frame,
     },
 
-  local linear(what, values, x) =
+  local linear(what, values, x, frame) =
     assert std.isArray(values);
     {
       kind: what,
       degree: 'linear',
       values: values,
       [if x != null then 'position']: x,
+      [if frame != null then 'frame']: frame,
     },
 
-  local dispatch(what, values, x) =
+  // A distributed load over the whole element, or over [a, b] when x = [a, b] is given. The frame
+  // is 'local' by default, or 'global' and 'projected' for loads in global directions, where the
+  // latter is per length of the projection perpendicular to the load.
+  local dispatch(what, values, x, frame) =
     if std.isNumber(values) then
-      constant(what, values)
+      constant(what, values, x, frame)
     else
-      linear(what, values, x),
+      linear(what, values, x, frame),
+
+  qx(values, x=null, frame=null):: [dispatch('qx', values, x, frame)],
+  qy(values, x=null, frame=null):: [dispatch('qy', values, x, frame)],
+  qz(values, x=null, frame=null):: [dispatch('qz', values, x, frame)],
+  mx(values, x=null):: [dispatch('mx', values, x, null)],
+  my(values, x=null):: [dispatch('my', values, x, null)],
+  mz(values, x=null):: [dispatch('mz', values, x, null)],
+
+  // A distributed load q(x) = Σ coefficients[i]·xⁱ with x the element coordinate, also for x = [a, b].
+  local polynomial(what, coefficients, x, frame) = {
+    kind: what,
+    degree: 'polynomial',
+    values: coefficients,
+    [if x != null then 'position']: x,
+    [if frame != null then 'frame']: frame,
+  },
+
+  qxPolynomial(coefficients, x=null, frame=null):: [polynomial('qx', coefficients, x, frame)],
+  qyPolynomial(coefficients, x=null, frame=null):: [polynomial('qy', coefficients, x, frame)],
+  qzPolynomial(coefficients, x=null, frame=null):: [polynomial('qz', coefficients, x, frame)],
 
-  qx(values, x=null):: [dispatch('qx', values, x)],
-  qy(values, x=null):: [dispatch('qy', values, x)],
-  qz(values, x=null):: [dispatch('qz', values, x)],
-  mx(values, x=null):: [dispatch('mx', values, x)],
-  my(values, x=null):: [dispatch('my', values, x)],
-  mz(values, x=null):: [dispatch('mz', values, x)],
+  // Axial element loads: imposed strain, length misfit (member too long), and prestress force.
+  Strain(value):: [constant('strain', value)],
+  Misfit(value):: [constant('misfit', value)],
+  Prestress(value):: [constant('prestress', value)],
 
-  LinElast(id, E, nu, rho)::
+  LinElast(id, E, nu, rho, fy=null)::
     {
       [id]: {
         kind: 'linelast',
@@ -70,6 +106,7 @@
           E: E,
           nu: nu,
           rho: rho,
+          [if fy != null then 'fy']: fy,
         },
       },
     },
@@ -86,7 +123,7 @@
       },
     },
 
-  Generic(id, A, Iyy, Izz, roll=0)::
+  Generic(id, A, Iyy, Izz, roll=0, zTop=null, zBottom=null, Avz=null)::
     {
       [id]: {
         kind: 'constants',
@@ -95,22 +132,26 @@
           Iyy: Iyy,
           Izz: Izz,
           [if roll != 0 then 'roll']: roll,
+          [if zTop != null then 'zTop']: zTop,
+          [if zBottom != null then 'zBottom']: zBottom,
+          [if Avz != null then 'Avz']: Avz,
         },
       },
     },
 
-  local element(nodes, hinges, kind, material, cs) = {
+  local element(nodes, hinges, kind, material, cs, offsets={}) = {
     kind: kind,
     [if material != null then 'material']: material,
     [if cs != null then 'cs']: cs,
     [if std.length(nodes) > 0 then 'nodes']: nodes,
     [if std.length(hinges) > 0 then 'hinges']: hinges,
+    [if std.length(offsets) > 0 then 'offsets']: offsets,
   },
 
   Truss2d(nodes=[], hinges={}, material=null, cs=null)::
     element(nodes, hinges, 'truss2d', material, cs),
   Truss3d(nodes=[], hinges={}, material=null, cs=null)::
     element(nodes, hinges, 'truss3d', material, cs),
-  Frame2d(nodes=[], hinges={}, material=null, cs=null)::
-    element(nodes, hinges, 'frame2d', material, cs),
+  Frame2d(nodes=[], hinges={}, material=null, cs=null, offsets={})::
+    element(nodes, hinges, 'frame2d', material, cs, offsets),
 }
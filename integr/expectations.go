@@ -3,11 +3,13 @@ package integr
 import (
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 	"testing"
 
 	"github.com/lubgr/deflect/deflect"
 	"gonum.org/v1/gonum/floats/scalar"
+	"gonum.org/v1/gonum/spatial/r3"
 )
 
 // Expectation offers an interface for integration tests to run assertions after solving a boundary
@@ -60,6 +62,7 @@ func (e *regexFailureExpectation) Failure(err error, t *testing.T) {
 type nodalExpectation struct {
 	noopExpectation
 	primary, reactions      []deflect.NodalValue
+	localReactions          []deflect.NodalValue
 	tolPrimary, tolReaction float64
 }
 
@@ -79,6 +82,7 @@ func newNodalExpectation(primary, reaction *float64) nodalExpectation {
 func (e *nodalExpectation) Reaction(r deflect.ProblemResult, t *testing.T) {
 	t.Helper()
 	e.nodal("reaction", r.Reaction, e.reactions, e.tolReaction, t)
+	e.nodal("local reaction", r.LocalReaction, e.localReactions, e.tolReaction, t)
 }
 
 func (e *nodalExpectation) Primary(r deflect.ProblemResult, t *testing.T) {
@@ -229,3 +233,207 @@ func (e *interpolationExpectation) testInterpolation(
 		}
 	}
 }
+
+type energyExpectation struct {
+	noopExpectation
+	tolerance float64
+	strain    *float64
+	balanced  bool
+}
+
+func (e *energyExpectation) Interpolated(r deflect.ProblemResult, t *testing.T) {
+	t.Helper()
+
+	balance, err := r.Energy(e.tolerance)
+
+	if err != nil {
+		t.Errorf("Couldn't compute energy balance: %v", err)
+		return
+	}
+
+	if e.strain != nil &&
+		!scalar.EqualWithinAbsOrRel(balance.StrainEnergy, *e.strain, e.tolerance, e.tolerance) {
+		t.Errorf("Expected strain energy to be %v, got %v", *e.strain, balance.StrainEnergy)
+	}
+
+	if imbalance := balance.Imbalance(); e.balanced && imbalance > e.tolerance {
+		t.Errorf("Expected strain energy to balance external work, got %+v (%v)", balance, imbalance)
+	}
+}
+
+type pointExpectation struct {
+	noopExpectation
+	tolerance float64
+	positions map[string][]expectedPointValues
+	points    []expectedPointValues
+}
+
+// expectedPointValues holds the expected components of point results, see pointComponents, either
+// at a relative position of an element or at a global point, that the given elements pass.
+type expectedPointValues struct {
+	ratio    float64
+	point    r3.Vec
+	elements []string
+	values   map[string]float64
+}
+
+// pointComponents returns the global components of a point result by the names of the nodal
+// values of the same kind.
+func pointComponents(pr deflect.PointResult) map[string]float64 {
+	return map[string]float64{
+		"Ux":   pr.Displacement.X,
+		"Uy":   pr.Displacement.Y,
+		"Uz":   pr.Displacement.Z,
+		"Phix": pr.Rotation.X,
+		"Phiy": pr.Rotation.Y,
+		"Phiz": pr.Rotation.Z,
+		"Fx":   pr.Force.X,
+		"Fy":   pr.Force.Y,
+		"Fz":   pr.Force.Z,
+		"Mx":   pr.Moment.X,
+		"My":   pr.Moment.Y,
+		"Mz":   pr.Moment.Z,
+	}
+}
+
+func (e *pointExpectation) Interpolated(r deflect.ProblemResult, t *testing.T) {
+	t.Helper()
+
+	for elmtID, expected := range e.positions {
+		for _, expect := range expected {
+			actual, err := r.AtPosition(elmtID, expect.ratio, e.tolerance)
+
+			if err != nil {
+				t.Errorf("Couldn't evaluate %v at %v: %v", elmtID, expect.ratio, err)
+				continue
+			}
+
+			what := fmt.Sprintf("%v at %v", elmtID, expect.ratio)
+			compareComponents(what, pointComponents(actual), expect.values, e.tolerance, t)
+		}
+	}
+
+	for _, expect := range e.points {
+		// Node coordinates are exact, so the geometric tolerance only needs to cover round-off.
+		actual, err := r.AtPoint(expect.point, 1e-9, e.tolerance)
+
+		if err != nil {
+			t.Errorf("Couldn't evaluate point %v: %v", expect.point, err)
+			continue
+		}
+
+		var elements []string
+
+		for _, single := range actual {
+			elements = append(elements, single.Element)
+			what := fmt.Sprintf("%v at %v", single.Element, expect.point)
+			compareComponents(what, pointComponents(single), expect.values, e.tolerance, t)
+		}
+
+		slices.Sort(elements)
+
+		if !slices.Equal(elements, expect.elements) {
+			t.Errorf("Expected elements %v at %v, got %v", expect.elements, expect.point, elements)
+		}
+	}
+}
+
+// compareComponents tests the expected values by name against the actual ones.
+func compareComponents(
+	what string,
+	actual, expected map[string]float64,
+	tol float64,
+	t *testing.T,
+) {
+	t.Helper()
+
+	for name, value := range expected {
+		if !scalar.EqualWithinAbsOrRel(actual[name], value, 1e-8, tol) {
+			t.Errorf("Expected %v of %v to be %v, got %v", name, what, value, actual[name])
+		}
+	}
+}
+
+type deformedExpectation struct {
+	noopExpectation
+	tolerance float64
+	scale     float64
+	segments  int
+	points    map[string][]r3.Vec
+}
+
+func (e *deformedExpectation) Interpolated(r deflect.ProblemResult, t *testing.T) {
+	t.Helper()
+
+	shapes, err := r.DeformedShape(e.scale, e.segments, e.tolerance)
+
+	if err != nil {
+		t.Errorf("Couldn't sample deformed shape: %v", err)
+		return
+	}
+
+	for elmtID, expected := range e.points {
+		idx := slices.IndexFunc(shapes, func(d deflect.DeformedElement) bool {
+			return d.Element == elmtID
+		})
+
+		if idx == -1 {
+			t.Errorf("No deformed shape of element %v", elmtID)
+			continue
+		} else if actual := shapes[idx].Points; len(actual) != len(expected) {
+			t.Errorf("Expected %v deformed points of %v, got %v", len(expected), elmtID, actual)
+			continue
+		}
+
+		for i, point := range shapes[idx].Points {
+			approxEq := func(a, b float64) bool {
+				return scalar.EqualWithinAbsOrRel(a, b, 1e-8, e.tolerance)
+			}
+
+			if want := expected[i]; !approxEq(point.X, want.X) || !approxEq(point.Y, want.Y) ||
+				!approxEq(point.Z, want.Z) {
+				t.Errorf("Expected deformed point %v of %v to be %v, got %v", i, elmtID, want, point)
+			}
+		}
+	}
+}
+
+type endForcesExpectation struct {
+	noopExpectation
+	tolerance float64
+	// The expected components of the start and end forces by element ID, see endForceComponents.
+	forces map[string][2]map[string]float64
+}
+
+// endForceComponents returns the local components N, V, M of an end force, and the global ones by
+// the names of nodal reactions.
+func endForceComponents(f deflect.EndForce) map[string]float64 {
+	return map[string]float64{
+		"N":  f.N,
+		"V":  f.V,
+		"M":  f.M,
+		"Fx": f.Force.X,
+		"Fy": f.Force.Y,
+		"Fz": f.Force.Z,
+		"Mx": f.Moment.X,
+		"My": f.Moment.Y,
+		"Mz": f.Moment.Z,
+	}
+}
+
+func (e *endForcesExpectation) Interpolated(r deflect.ProblemResult, t *testing.T) {
+	t.Helper()
+
+	for elmtID, expected := range e.forces {
+		actual, err := r.EndForces(elmtID, e.tolerance)
+
+		if err != nil {
+			t.Errorf("Couldn't determine end forces of %v: %v", elmtID, err)
+			continue
+		}
+
+		start, end := endForceComponents(actual.Start), endForceComponents(actual.End)
+		compareComponents(elmtID+" start", start, expected[0], e.tolerance, t)
+		compareComponents(elmtID+" end", end, expected[1], e.tolerance, t)
+	}
+}
@@ -9,13 +9,20 @@ import (
 	"slices"
 
 	"github.com/lubgr/deflect/deflect"
+	"gonum.org/v1/gonum/spatial/r3"
 )
 
 type expectedDescription struct {
-	Tolerance     struct{ Primary, Reaction, Polynomial *float64 }
+	Tolerance     struct{ Primary, Reaction, Polynomial, Energy, Point *float64 }
 	Primary       map[string][]nodalValues
 	Reaction      map[string][]nodalValues
+	LocalReaction map[string][]nodalValues
 	Interpolation map[string][]expectedInterpolationDescription
+	Energy        *expectedEnergyDescription
+	Position      map[string][]expectedPositionDescription
+	Point         []expectedPointDescription
+	Deformed      *expectedDeformedDescription
+	EndForces     map[string]expectedEndForcesDescription
 	// A regular expression for the error description. If this field is not specified, success is
 	// assumed and tested for.
 	Failure *string
@@ -23,6 +30,45 @@ type expectedDescription struct {
 
 type nodalValues map[string]float64
 
+type expectedEnergyDescription struct {
+	// The total strain energy of all elements. Not tested if nil.
+	Strain *float64
+	// Unless false, the strain energy must be balanced by the work of all loads, see
+	// [deflect.EnergyBalance]. Problems that don't expect a failure are checked by default.
+	Balanced *bool
+}
+
+// Point results are described by the names of nodal values, where Ux, Uy, Uz are the components of
+// the displacement, Phix, Phiy, Phiz of the rotation, Fx, Fy, Fz of the section force, and Mx, My,
+// Mz of the section moment, all in global coordinates. See [deflect.PointResult].
+type expectedPositionDescription struct {
+	// The relative position along the element, between 0 and 1.
+	At     float64
+	Values []nodalValues
+}
+
+type expectedPointDescription struct {
+	// Global coordinates of the point, and the IDs of all elements that pass it.
+	At       []float64
+	Elements []string
+	Values   []nodalValues
+}
+
+type expectedDeformedDescription struct {
+	// Arguments of [deflect.ProblemResult.DeformedShape].
+	Scale    float64
+	Segments int
+	// The deformed points in global coordinates by element ID. All points of an element must be
+	// given.
+	Points map[string][][]float64
+}
+
+// End forces are described by their local components N, V, M, and the global ones by the names of
+// nodal reactions. See [deflect.EndForce].
+type expectedEndForcesDescription struct {
+	Start, End []nodalValues
+}
+
 type expectedInterpolationDescription struct {
 	Kind   string
 	Degree int
@@ -82,13 +128,14 @@ func expectationsFromDescriptions(expect expectedDescription) ([]Expectation, er
 	}
 
 	nodal := newNodalExpectation(expect.Tolerance.Primary, expect.Tolerance.Reaction)
-	var errPrimary, errReact error
+	var errPrimary, errReact, errLocal error
 	nodal.primary, errPrimary = translateToNodalExpectations(expect.Primary)
 	nodal.reactions, errReact = translateToNodalExpectations(expect.Reaction)
+	nodal.localReactions, errLocal = translateToNodalExpectations(expect.LocalReaction)
 
-	if err := errors.Join(errPrimary, errReact); err != nil {
+	if err := errors.Join(errPrimary, errReact, errLocal); err != nil {
 		return nil, fmt.Errorf("failed to build nodal expectations: %w", err)
-	} else if len(nodal.primary)+len(nodal.reactions) > 0 {
+	} else if len(nodal.primary)+len(nodal.reactions)+len(nodal.localReactions) > 0 {
 		result = append(result, &nodal)
 	}
 
@@ -103,6 +150,52 @@ func expectationsFromDescriptions(expect expectedDescription) ([]Expectation, er
 
 	result = append(result, interpolation)
 
+	// Every problem that is expected to be solved must satisfy the energy balance by default.
+	if expect.Energy != nil || expect.Failure == nil {
+		energy := energyExpectation{tolerance: 1e-8, balanced: true}
+
+		if expect.Energy != nil {
+			energy.strain = expect.Energy.Strain
+			energy.balanced = expect.Energy.Balanced == nil || *expect.Energy.Balanced
+		}
+
+		if expect.Tolerance.Energy != nil {
+			energy.tolerance = *expect.Tolerance.Energy
+		}
+
+		result = append(result, &energy)
+	}
+
+	if len(expect.Position)+len(expect.Point) > 0 {
+		point, err := translatePointResults(expect.Position, expect.Point, expect.Tolerance.Point)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to build test expectations for point results: %w", err)
+		}
+
+		result = append(result, point)
+	}
+
+	if expect.Deformed != nil {
+		deformed, err := translateDeformed(expect.Deformed, expect.Tolerance.Point)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to build test expectations for deformed shapes: %w", err)
+		}
+
+		result = append(result, deformed)
+	}
+
+	if len(expect.EndForces) > 0 {
+		forces, err := translateEndForces(expect.EndForces, expect.Tolerance.Reaction)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to build test expectations for end forces: %w", err)
+		}
+
+		result = append(result, forces)
+	}
+
 	return result, nil
 }
 
@@ -174,18 +267,20 @@ func translateInterpolations(
 
 func translateInterpolation(from *expectedInterpolationDescription) (expectedPolynomial, error) {
 	quantities := map[string]deflect.Fct{
-		"Nx":   deflect.FctNx,
-		"Vz":   deflect.FctVz,
-		"Vy":   deflect.FctVy,
-		"My":   deflect.FctMy,
-		"Mz":   deflect.FctMz,
-		"Mx":   deflect.FctMx,
-		"Ux":   deflect.FctUx,
-		"Uz":   deflect.FctUz,
-		"Uy":   deflect.FctUy,
-		"Phiy": deflect.FctPhiy,
-		"Phiz": deflect.FctPhiz,
-		"Phix": deflect.FctPhix,
+		"Nx":     deflect.FctNx,
+		"Vz":     deflect.FctVz,
+		"Vy":     deflect.FctVy,
+		"My":     deflect.FctMy,
+		"Mz":     deflect.FctMz,
+		"Mx":     deflect.FctMx,
+		"Ux":     deflect.FctUx,
+		"Uz":     deflect.FctUz,
+		"Uy":     deflect.FctUy,
+		"Phiy":   deflect.FctPhiy,
+		"Phiz":   deflect.FctPhiz,
+		"Phix":   deflect.FctPhix,
+		"Epsx":   deflect.FctEpsx,
+		"Kappay": deflect.FctKappay,
 	}
 
 	var p expectedPolynomial
@@ -219,3 +314,125 @@ func translateInterpolation(from *expectedInterpolationDescription) (expectedPol
 
 	return p, nil
 }
+
+func translatePointResults(
+	positions map[string][]expectedPositionDescription,
+	points []expectedPointDescription,
+	tol *float64,
+) (Expectation, error) {
+	expect := pointExpectation{tolerance: 1e-8, positions: map[string][]expectedPointValues{}}
+	names := pointComponents(deflect.PointResult{})
+
+	if tol != nil {
+		expect.tolerance = *tol
+	}
+
+	for elmtID, descriptions := range positions {
+		for _, desc := range descriptions {
+			values, err := translateComponents(desc.Values, names)
+
+			if err != nil {
+				return nil, fmt.Errorf("element %v at %v: %w", elmtID, desc.At, err)
+			}
+
+			expected := expectedPointValues{ratio: desc.At, values: values}
+			expect.positions[elmtID] = append(expect.positions[elmtID], expected)
+		}
+	}
+
+	for _, desc := range points {
+		if len(desc.At) != 3 {
+			return nil, fmt.Errorf("point %v must have three coordinates", desc.At)
+		}
+
+		values, err := translateComponents(desc.Values, names)
+
+		if err != nil {
+			return nil, fmt.Errorf("point %v: %w", desc.At, err)
+		}
+
+		expected := expectedPointValues{
+			point:    r3.Vec{X: desc.At[0], Y: desc.At[1], Z: desc.At[2]},
+			elements: slices.Clone(desc.Elements),
+			values:   values,
+		}
+		slices.Sort(expected.elements)
+		expect.points = append(expect.points, expected)
+	}
+
+	return &expect, nil
+}
+
+// translateComponents merges the given values into a single map, where all names must be keys of
+// the allowed map.
+func translateComponents(
+	from []nodalValues,
+	allowed map[string]float64,
+) (map[string]float64, error) {
+	result := map[string]float64{}
+	var err error
+
+	for _, values := range from {
+		for name, value := range values {
+			if _, ok := allowed[name]; !ok {
+				err = errors.Join(err, fmt.Errorf("unknown component '%v'", name))
+				continue
+			}
+
+			result[name] = value
+		}
+	}
+
+	return result, err
+}
+
+func translateDeformed(from *expectedDeformedDescription, tol *float64) (Expectation, error) {
+	expect := deformedExpectation{
+		tolerance: 1e-8,
+		scale:     from.Scale,
+		segments:  from.Segments,
+		points:    map[string][]r3.Vec{},
+	}
+
+	if tol != nil {
+		expect.tolerance = *tol
+	}
+
+	for elmtID, points := range from.Points {
+		for _, point := range points {
+			if len(point) != 3 {
+				return nil, fmt.Errorf("deformed point %v of %v must have three coordinates", point, elmtID)
+			}
+
+			vec := r3.Vec{X: point[0], Y: point[1], Z: point[2]}
+			expect.points[elmtID] = append(expect.points[elmtID], vec)
+		}
+	}
+
+	return &expect, nil
+}
+
+func translateEndForces(
+	from map[string]expectedEndForcesDescription,
+	tol *float64,
+) (Expectation, error) {
+	expect := endForcesExpectation{tolerance: 1e-8, forces: map[string][2]map[string]float64{}}
+	names := endForceComponents(deflect.EndForce{})
+
+	if tol != nil {
+		expect.tolerance = *tol
+	}
+
+	for elmtID, desc := range from {
+		start, errStart := translateComponents(desc.Start, names)
+		end, errEnd := translateComponents(desc.End, names)
+
+		if err := errors.Join(errStart, errEnd); err != nil {
+			return nil, fmt.Errorf("element %v: %w", elmtID, err)
+		}
+
+		expect.forces[elmtID] = [2]map[string]float64{start, end}
+	}
+
+	return &expect, nil
+}
@@ -131,13 +131,43 @@ func runTestCase(input []byte, t *testing.T) {
 		return
 	}
 
-	solver := deflect.NewLinearProblemSolver()
-	result, err := solver.Solve(&problem, indices, deflect.NewCholeskySolver())
-
-	for _, e := range expect {
-		e.Failure(err, t)
-		e.Primary(result, t)
-		e.Reaction(result, t)
-		e.Interpolated(result, t)
+	// Every BVP is solved with all exact enforcements of Dirichlet BCs, which must yield identical
+	// results. Penalty enforcement is approximate and hence not part of the integration tests.
+	variants := []struct {
+		name     string
+		solver   deflect.ProblemSolver
+		strategy deflect.EquationSolver
+	}{
+		{"partitioned", deflect.NewLinearProblemSolver(), deflect.NewCholeskySolver()},
+		{
+			"lagrange",
+			deflect.NewLinearProblemSolver(deflect.WithLagrangeMultipliers()),
+			deflect.NewLDLSolver(),
+		},
+		{
+			"parallel",
+			deflect.NewLinearProblemSolver(deflect.WithParallelAssembly(3)),
+			deflect.NewCholeskySolver(),
+		},
+	}
+
+	for _, variant := range variants {
+		t.Run(variant.name, func(t *testing.T) {
+			result, err := variant.solver.Solve(&problem, indices, variant.strategy)
+
+			// The equilibrium check is a safety net for every successfully solved problem.
+			if err == nil {
+				for _, warning := range result.Warnings() {
+					t.Errorf("Unexpected warning: %v", warning)
+				}
+			}
+
+			for _, e := range expect {
+				e.Failure(err, t)
+				e.Primary(result, t)
+				e.Reaction(result, t)
+				e.Interpolated(result, t)
+			}
+		})
 	}
 }
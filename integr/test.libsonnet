@@ -17,7 +17,10 @@ local bvp = import 'bvp.libsonnet';
   My(value, x=null):: bvp.My(value, x),
   Mz(value, x=null):: bvp.Mz(value, x),
 
-  local allowedPolynomials = ['Ux', 'Uz', 'Uy', 'Phiy', 'Phiz', 'Phix', 'Nx', 'Vz', 'Vy', 'My', 'Mz', 'Mx'],
+  local allowedPolynomials = [
+    'Ux', 'Uz', 'Uy', 'Phiy', 'Phiz', 'Phix', 'Nx', 'Vz', 'Vy', 'My', 'Mz', 'Mx',
+    'Epsx', 'Kappay',
+  ],
 
   Constant(kind, value, range=null)::
     assert std.member(allowedPolynomials, kind) : "Unknown function '%s'" % kind;
@@ -54,6 +57,17 @@ local bvp = import 'bvp.libsonnet';
   Quartic(kind, eval=null, range=null):: [higherOrder(kind, 4, eval, range)],
   Quintic(kind, eval=null, range=null):: [higherOrder(kind, 5, eval, range)],
 
+  // Local components of element end forces, which can be combined with the global Fx, My etc.
+  N(value):: [{ N: value }],
+  V(value):: [{ V: value }],
+  M(value):: [{ M: value }],
+
+  // Expected point results at the relative position of an element, or at a global point that the
+  // given elements pass. The values are composed of the primitives above in global coordinates,
+  // where the forces and moments are section forces.
+  At(ratio, values):: [{ at: ratio, values: values }],
+  Point(at, elements, values):: [{ at: at, elements: elements, values: values }],
+
   Samples(f, x0, xE, n):: [
     [x, f(x)]
     for x in [x0 + i * (xE - x0) / n for i in std.range(0, n)]